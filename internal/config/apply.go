@@ -0,0 +1,83 @@
+package config
+
+import "fmt"
+
+// Tmux abstracts the tmux operations Apply needs, so session templates can
+// be exercised in tests without a real tmux server.
+type Tmux interface {
+	NewSession(name, root string) error
+	NewWindow(session, name, root string) error
+	SplitWindow(target, root string) error
+	SelectLayout(target, layout string) error
+	SendKeys(target, command string) error
+}
+
+// Apply creates a tmux session from cfg: the session's initial window
+// becomes the first declared window, subsequent windows are created in
+// order, and each window's panes are split and given their commands before
+// its layout (if any) is applied.
+func Apply(cfg *Config, tmux Tmux) error {
+	if len(cfg.Windows) == 0 {
+		return tmux.NewSession(cfg.Session, cfg.Root)
+	}
+
+	first := cfg.Windows[0]
+	if err := tmux.NewSession(cfg.Session, windowRoot(cfg, first)); err != nil {
+		return fmt.Errorf("failed to create session %q: %w", cfg.Session, err)
+	}
+	if err := applyWindow(cfg, first, tmux); err != nil {
+		return err
+	}
+
+	for _, window := range cfg.Windows[1:] {
+		if err := tmux.NewWindow(cfg.Session, window.Name, windowRoot(cfg, window)); err != nil {
+			return fmt.Errorf("failed to create window %q: %w", window.Name, err)
+		}
+		if err := applyWindow(cfg, window, tmux); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// windowRoot returns the window's own root if set, otherwise the session's.
+func windowRoot(cfg *Config, window Window) string {
+	if window.Root != "" {
+		return window.Root
+	}
+	return cfg.Root
+}
+
+// applyWindow splits panes for window (the window itself always starts with
+// one implicit pane, so only panes after the first require a split) and
+// runs each pane's commands, then applies the window's layout if declared.
+func applyWindow(cfg *Config, window Window, tmux Tmux) error {
+	target := cfg.Session + ":" + window.Name
+
+	for i, pane := range window.Panes {
+		if i > 0 {
+			root := pane.Root
+			if root == "" {
+				root = windowRoot(cfg, window)
+			}
+			if err := tmux.SplitWindow(target, root); err != nil {
+				return fmt.Errorf("failed to split pane in window %q: %w", window.Name, err)
+			}
+		}
+
+		for _, command := range pane.Commands {
+			if err := tmux.SendKeys(target, command); err != nil {
+				return fmt.Errorf("failed to run command in window %q: %w", window.Name, err)
+			}
+		}
+	}
+
+	if window.Layout != "" {
+		if err := tmux.SelectLayout(target, window.Layout); err != nil {
+			return fmt.Errorf("failed to select layout for window %q: %w", window.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+// fakeTmux records the calls Apply makes instead of shelling out to tmux.
+type fakeTmux struct {
+	calls []string
+}
+
+func (f *fakeTmux) NewSession(name, root string) error {
+	f.calls = append(f.calls, "new-session "+name+" "+root)
+	return nil
+}
+
+func (f *fakeTmux) NewWindow(session, name, root string) error {
+	f.calls = append(f.calls, "new-window "+session+" "+name+" "+root)
+	return nil
+}
+
+func (f *fakeTmux) SplitWindow(target, root string) error {
+	f.calls = append(f.calls, "split-window "+target+" "+root)
+	return nil
+}
+
+func (f *fakeTmux) SelectLayout(target, layout string) error {
+	f.calls = append(f.calls, "select-layout "+target+" "+layout)
+	return nil
+}
+
+func (f *fakeTmux) SendKeys(target, command string) error {
+	f.calls = append(f.calls, "send-keys "+target+" "+command)
+	return nil
+}
+
+func TestApply(t *testing.T) {
+	cfg := &Config{
+		Session: "myproject",
+		Root:    "/projects/myproject",
+		Windows: []Window{
+			{
+				Name:  "editor",
+				Panes: []Pane{{Commands: []string{"vim"}}},
+			},
+			{
+				Name:   "server",
+				Layout: "main-vertical",
+				Panes: []Pane{
+					{Commands: []string{"npm run dev"}},
+					{Root: "/projects/myproject/logs", Commands: []string{"tail -f app.log"}},
+				},
+			},
+		},
+	}
+
+	tmux := &fakeTmux{}
+	if err := Apply(cfg, tmux); err != nil {
+		t.Fatalf("Apply returned error: %v", err)
+	}
+
+	expected := []string{
+		"new-session myproject /projects/myproject",
+		"send-keys myproject:editor vim",
+		"new-window myproject server /projects/myproject",
+		"send-keys myproject:server npm run dev",
+		"split-window myproject:server /projects/myproject/logs",
+		"send-keys myproject:server tail -f app.log",
+		"select-layout myproject:server main-vertical",
+	}
+	if !reflect.DeepEqual(tmux.calls, expected) {
+		t.Errorf("unexpected call sequence:\n got:  %v\n want: %v", tmux.calls, expected)
+	}
+}
+
+func TestApply_NoWindows(t *testing.T) {
+	cfg := &Config{Session: "bare", Root: "/projects/bare"}
+
+	tmux := &fakeTmux{}
+	if err := Apply(cfg, tmux); err != nil {
+		t.Fatalf("Apply returned error: %v", err)
+	}
+
+	expected := []string{"new-session bare /projects/bare"}
+	if !reflect.DeepEqual(tmux.calls, expected) {
+		t.Errorf("unexpected call sequence:\n got:  %v\n want: %v", tmux.calls, expected)
+	}
+}
@@ -0,0 +1,91 @@
+// Package config loads per-project session templates describing a tmux
+// session's windows, panes, and startup commands, following the
+// smug/tmuxinator format:
+//
+//	session: myproject
+//	root: ~/projects/myproject
+//	windows:
+//	  - name: editor
+//	    panes:
+//	      - commands: ["vim"]
+//	  - name: server
+//	    layout: main-vertical
+//	    panes:
+//	      - commands: ["npm run dev"]
+//	      - commands: ["npm test -- --watch"]
+//
+// Templates are expanded against a caller-supplied variable map (e.g.
+// ${branch}, ${repo}, or arbitrary -s key=value overrides) before parsing.
+//
+// This repo has no main/cmd package, so a "claude-tmux up <project>" CLI
+// subcommand doesn't exist to call into; the exported functions here (Up
+// foremost) are the library entry points a future CLI (or the TUI) would
+// wire up to.
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is a parsed session template.
+type Config struct {
+	Session string   `yaml:"session"`
+	Root    string   `yaml:"root"`
+	Windows []Window `yaml:"windows"`
+}
+
+// Window is a single tmux window declared in a template.
+type Window struct {
+	Name   string `yaml:"name"`
+	Root   string `yaml:"root,omitempty"`
+	Layout string `yaml:"layout,omitempty"`
+	Panes  []Pane `yaml:"panes,omitempty"`
+}
+
+// Pane is a single tmux pane within a Window.
+type Pane struct {
+	Root     string   `yaml:"root,omitempty"`
+	Commands []string `yaml:"commands,omitempty"`
+}
+
+// Load reads the YAML template at path, expanding ${var} references against
+// vars before parsing.
+func Load(path string, vars map[string]string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template %s: %w", path, err)
+	}
+	return ParseConfig(data, vars)
+}
+
+// ParseConfig expands ${var} references in raw against vars, then unmarshals
+// the result as YAML. It is the seam unit tests use to exercise templates
+// without touching disk.
+func ParseConfig(raw []byte, vars map[string]string) (*Config, error) {
+	expanded := os.Expand(string(raw), func(key string) string {
+		return vars[key]
+	})
+
+	var cfg Config
+	if err := yaml.Unmarshal([]byte(expanded), &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	return &cfg, nil
+}
+
+// WindowIndex returns the position of the window named name in cfg's
+// declared order, or -1 if no such window exists. Since windows are created
+// in declaration order, this lets callers populate session.Session's
+// WindowIndex so session.SortSessions orders them the way the template
+// declared, without waiting on tmux to report pane placement.
+func WindowIndex(cfg *Config, name string) int {
+	for i, window := range cfg.Windows {
+		if window.Name == name {
+			return i
+		}
+	}
+	return -1
+}
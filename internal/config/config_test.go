@@ -0,0 +1,88 @@
+package config
+
+import "testing"
+
+func TestParseConfig(t *testing.T) {
+	raw := []byte(`
+session: ${repo}-${branch}
+root: /projects/${repo}
+windows:
+  - name: editor
+    panes:
+      - commands: ["vim"]
+  - name: server
+    layout: main-vertical
+    panes:
+      - commands: ["npm run dev"]
+      - commands: ["npm test -- --watch"]
+`)
+
+	cfg, err := ParseConfig(raw, map[string]string{"repo": "api-server", "branch": "main"})
+	if err != nil {
+		t.Fatalf("ParseConfig returned error: %v", err)
+	}
+
+	if cfg.Session != "api-server-main" {
+		t.Errorf("expected session %q, got %q", "api-server-main", cfg.Session)
+	}
+	if cfg.Root != "/projects/api-server" {
+		t.Errorf("expected root %q, got %q", "/projects/api-server", cfg.Root)
+	}
+	if len(cfg.Windows) != 2 {
+		t.Fatalf("expected 2 windows, got %d", len(cfg.Windows))
+	}
+	if cfg.Windows[1].Layout != "main-vertical" {
+		t.Errorf("expected layout %q, got %q", "main-vertical", cfg.Windows[1].Layout)
+	}
+	if len(cfg.Windows[1].Panes) != 2 {
+		t.Errorf("expected 2 panes in window %q, got %d", cfg.Windows[1].Name, len(cfg.Windows[1].Panes))
+	}
+}
+
+func TestParseConfig_MissingVariableExpandsEmpty(t *testing.T) {
+	raw := []byte(`session: ${unset}`)
+
+	cfg, err := ParseConfig(raw, map[string]string{})
+	if err != nil {
+		t.Fatalf("ParseConfig returned error: %v", err)
+	}
+	if cfg.Session != "" {
+		t.Errorf("expected empty session for unset variable, got %q", cfg.Session)
+	}
+}
+
+func TestWindowIndex(t *testing.T) {
+	cfg := &Config{
+		Windows: []Window{
+			{Name: "editor"},
+			{Name: "server"},
+			{Name: "logs"},
+		},
+	}
+
+	if idx := WindowIndex(cfg, "server"); idx != 1 {
+		t.Errorf("expected index 1 for 'server', got %d", idx)
+	}
+	if idx := WindowIndex(cfg, "missing"); idx != -1 {
+		t.Errorf("expected -1 for unknown window, got %d", idx)
+	}
+}
+
+func TestFindTemplate(t *testing.T) {
+	configs := []*Config{
+		{Session: "api"},
+		{Session: "web"},
+	}
+
+	cfg, ok := FindTemplate(configs, "web")
+	if !ok {
+		t.Fatal("expected to find template 'web'")
+	}
+	if cfg.Session != "web" {
+		t.Errorf("expected session %q, got %q", "web", cfg.Session)
+	}
+
+	if _, ok := FindTemplate(configs, "missing"); ok {
+		t.Error("expected no match for 'missing'")
+	}
+}
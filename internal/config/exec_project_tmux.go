@@ -0,0 +1,48 @@
+package config
+
+import "os/exec"
+
+// execProjectTmux is the default ProjectTmux implementation, shelling out to
+// the real tmux binary.
+type execProjectTmux struct{}
+
+// DefaultProjectTmux returns the ProjectTmux implementation Up should use
+// outside of tests.
+func DefaultProjectTmux() ProjectTmux {
+	return execProjectTmux{}
+}
+
+func (execProjectTmux) NewSession(name, root string) error {
+	args := []string{"new-session", "-d", "-s", name}
+	if root != "" {
+		args = append(args, "-c", root)
+	}
+	return exec.Command("tmux", args...).Run()
+}
+
+func (execProjectTmux) NewWindow(session, name, root string) error {
+	args := []string{"new-window", "-t", session, "-n", name}
+	if root != "" {
+		args = append(args, "-c", root)
+	}
+	return exec.Command("tmux", args...).Run()
+}
+
+func (execProjectTmux) SplitWindow(target, direction, root string) error {
+	args := []string{"split-window", "-t", target}
+	if direction != "" {
+		args = append(args, direction)
+	}
+	if root != "" {
+		args = append(args, "-c", root)
+	}
+	return exec.Command("tmux", args...).Run()
+}
+
+func (execProjectTmux) SelectLayout(target, layout string) error {
+	return exec.Command("tmux", "select-layout", "-t", target, layout).Run()
+}
+
+func (execProjectTmux) SendKeys(target, command string) error {
+	return exec.Command("tmux", "send-keys", "-t", target, command, "Enter").Run()
+}
@@ -0,0 +1,44 @@
+package config
+
+import "os/exec"
+
+// execTmux is the default Tmux implementation, shelling out to the real
+// tmux binary.
+type execTmux struct{}
+
+// DefaultTmux returns the Tmux implementation Apply should use outside of tests.
+func DefaultTmux() Tmux {
+	return execTmux{}
+}
+
+func (execTmux) NewSession(name, root string) error {
+	args := []string{"new-session", "-d", "-s", name}
+	if root != "" {
+		args = append(args, "-c", root)
+	}
+	return exec.Command("tmux", args...).Run()
+}
+
+func (execTmux) NewWindow(session, name, root string) error {
+	args := []string{"new-window", "-t", session, "-n", name}
+	if root != "" {
+		args = append(args, "-c", root)
+	}
+	return exec.Command("tmux", args...).Run()
+}
+
+func (execTmux) SplitWindow(target, root string) error {
+	args := []string{"split-window", "-t", target}
+	if root != "" {
+		args = append(args, "-c", root)
+	}
+	return exec.Command("tmux", args...).Run()
+}
+
+func (execTmux) SelectLayout(target, layout string) error {
+	return exec.Command("tmux", "select-layout", "-t", target, layout).Run()
+}
+
+func (execTmux) SendKeys(target, command string) error {
+	return exec.Command("tmux", "send-keys", "-t", target, command, "Enter").Run()
+}
@@ -0,0 +1,167 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Project is a declarative tmux layout for spawning a Claude Code session,
+// in the spirit of tmuxctl/tmuxinator's project files but aimed at the
+// specific windows/panes/commands this tool needs to hand off to "up":
+//
+//	[[project]]
+//	name = "api"
+//	dir = "~/src/api"
+//
+//	  [[project.windows]]
+//	  name = "claude"
+//
+//	    [[project.windows.panes]]
+//	    cmd = "claude"
+//
+//	    [[project.windows.panes]]
+//	    split = "-h"
+//	    cmd = "npm run dev"
+//
+// Unlike the YAML Config/Window/Pane used by session templates (which apply
+// a single named Layout to a window's panes), ProjectPane declares its split
+// direction directly, since Up issues one tmux command per pane as it's
+// created rather than arranging them after the fact.
+type Project struct {
+	Name    string          `toml:"name"`
+	Dir     string          `toml:"dir"`
+	Windows []ProjectWindow `toml:"windows"`
+}
+
+// ProjectWindow is a single tmux window within a Project.
+type ProjectWindow struct {
+	Name   string        `toml:"name"`
+	Layout string        `toml:"layout"` // optional named layout applied after all panes are split
+	Panes  []ProjectPane `toml:"panes"`
+}
+
+// ProjectPane is a single tmux pane within a ProjectWindow. The first pane
+// in a window is always the one new-session/new-window creates implicitly,
+// so its Split is ignored; later panes split the window to make room for
+// themselves.
+type ProjectPane struct {
+	Split string `toml:"split"` // tmux split-window flag: "-h", "-v", or "" (only meaningful after the first pane)
+	Cmd   string `toml:"cmd"`   // command sent to the pane once it's created, if any
+}
+
+// ProjectsFile is the top-level shape of a projects TOML file: a flat list
+// of [[project]] tables.
+type ProjectsFile struct {
+	Projects []Project `toml:"project"`
+}
+
+// knownSplits are the tmux split-window flags ProjectPane.Split may use.
+var knownSplits = map[string]bool{
+	"":   true,
+	"-h": true,
+	"-v": true,
+}
+
+// knownLayouts are tmux's built-in named layouts, the only values
+// ProjectWindow.Layout may take.
+var knownLayouts = map[string]bool{
+	"":                true,
+	"even-horizontal": true,
+	"even-vertical":   true,
+	"main-horizontal": true,
+	"main-vertical":   true,
+	"tiled":           true,
+}
+
+// LoadProjects reads and parses a TOML projects file at path.
+func LoadProjects(path string) (*ProjectsFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read projects file %s: %w", path, err)
+	}
+	return ParseProjects(data)
+}
+
+// ParseProjects unmarshals raw as a ProjectsFile. It is the seam unit tests
+// use to exercise project parsing without touching disk.
+func ParseProjects(raw []byte) (*ProjectsFile, error) {
+	var file ProjectsFile
+	if _, err := toml.Decode(string(raw), &file); err != nil {
+		return nil, fmt.Errorf("failed to parse projects file: %w", err)
+	}
+	return &file, nil
+}
+
+// FindProject returns the project named name, if any.
+func FindProject(file *ProjectsFile, name string) (Project, bool) {
+	for _, p := range file.Projects {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Project{}, false
+}
+
+// Validate checks p for the mistakes that would otherwise only surface once
+// Up starts issuing tmux commands: no name, no windows, an unknown split or
+// layout value, or a dir that doesn't exist.
+func (p Project) Validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("project: name is required")
+	}
+	if len(p.Windows) == 0 {
+		return fmt.Errorf("project %q: at least one window is required", p.Name)
+	}
+
+	dir, err := ExpandDir(p.Dir)
+	if err != nil {
+		return fmt.Errorf("project %q: %w", p.Name, err)
+	}
+	if dir != "" {
+		info, err := os.Stat(dir)
+		if err != nil || !info.IsDir() {
+			return fmt.Errorf("project %q: dir %q does not exist", p.Name, p.Dir)
+		}
+	}
+
+	for _, window := range p.Windows {
+		if !knownLayouts[window.Layout] {
+			return fmt.Errorf("project %q: window %q has unknown layout %q", p.Name, window.Name, window.Layout)
+		}
+		for _, pane := range window.Panes {
+			if !knownSplits[pane.Split] {
+				return fmt.Errorf("project %q: window %q has unknown split %q", p.Name, window.Name, pane.Split)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ExpandDir resolves a leading "~" in dir to the current user's home
+// directory, the way a shell would. An empty dir is returned as-is.
+func ExpandDir(dir string) (string, error) {
+	if dir == "" || dir == "~" {
+		if dir == "~" {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return "", fmt.Errorf("failed to resolve home directory: %w", err)
+			}
+			return home, nil
+		}
+		return "", nil
+	}
+	if !strings.HasPrefix(dir, "~"+string(filepath.Separator)) {
+		return dir, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve home directory: %w", err)
+	}
+	return filepath.Join(home, dir[2:]), nil
+}
@@ -0,0 +1,148 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseProjects(t *testing.T) {
+	raw := []byte(`
+[[project]]
+name = "api"
+dir = "/projects/api"
+
+  [[project.windows]]
+  name = "claude"
+
+    [[project.windows.panes]]
+    cmd = "claude"
+
+  [[project.windows]]
+  name = "server"
+  layout = "main-vertical"
+
+    [[project.windows.panes]]
+    cmd = "npm run dev"
+
+    [[project.windows.panes]]
+    split = "-h"
+    cmd = "tail -f app.log"
+`)
+
+	file, err := ParseProjects(raw)
+	if err != nil {
+		t.Fatalf("ParseProjects returned error: %v", err)
+	}
+
+	p, ok := FindProject(file, "api")
+	if !ok {
+		t.Fatal("expected to find project 'api'")
+	}
+	if p.Dir != "/projects/api" {
+		t.Errorf("expected dir /projects/api, got %q", p.Dir)
+	}
+	if len(p.Windows) != 2 {
+		t.Fatalf("expected 2 windows, got %d", len(p.Windows))
+	}
+	if p.Windows[1].Panes[1].Split != "-h" {
+		t.Errorf("expected second pane split -h, got %q", p.Windows[1].Panes[1].Split)
+	}
+}
+
+func TestLoadProjects(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "projects.toml")
+	content := "[[project]]\nname = \"api\"\n\n  [[project.windows]]\n  name = \"claude\"\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write projects file: %v", err)
+	}
+
+	file, err := LoadProjects(path)
+	if err != nil {
+		t.Fatalf("LoadProjects returned error: %v", err)
+	}
+	if _, ok := FindProject(file, "api"); !ok {
+		t.Error("expected to find project 'api'")
+	}
+}
+
+func TestLoadProjects_MissingFile(t *testing.T) {
+	if _, err := LoadProjects("/nonexistent/projects.toml"); err == nil {
+		t.Fatal("expected error for missing projects file")
+	}
+}
+
+func TestProjectValidate(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := Project{
+		Name: "api",
+		Dir:  dir,
+		Windows: []ProjectWindow{
+			{Name: "claude", Panes: []ProjectPane{{Cmd: "claude"}}},
+		},
+	}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("expected valid project to pass validation, got: %v", err)
+	}
+
+	if err := (Project{}).Validate(); err == nil {
+		t.Error("expected error for missing name")
+	}
+
+	if err := (Project{Name: "api"}).Validate(); err == nil {
+		t.Error("expected error for no windows")
+	}
+
+	missingDir := Project{
+		Name:    "api",
+		Dir:     filepath.Join(dir, "does-not-exist"),
+		Windows: []ProjectWindow{{Name: "claude"}},
+	}
+	if err := missingDir.Validate(); err == nil {
+		t.Error("expected error for nonexistent dir")
+	}
+
+	badLayout := Project{
+		Name:    "api",
+		Windows: []ProjectWindow{{Name: "claude", Layout: "not-a-layout"}},
+	}
+	if err := badLayout.Validate(); err == nil {
+		t.Error("expected error for unknown layout")
+	}
+
+	badSplit := Project{
+		Name: "api",
+		Windows: []ProjectWindow{
+			{Name: "claude", Panes: []ProjectPane{{Split: "-x"}}},
+		},
+	}
+	if err := badSplit.Validate(); err == nil {
+		t.Error("expected error for unknown split")
+	}
+}
+
+func TestExpandDir(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	tmpHome := t.TempDir()
+	os.Setenv("HOME", tmpHome)
+	defer os.Setenv("HOME", originalHome)
+
+	got, err := ExpandDir("~/src/api")
+	if err != nil {
+		t.Fatalf("ExpandDir returned error: %v", err)
+	}
+	want := filepath.Join(tmpHome, "src", "api")
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	if got, err := ExpandDir("/absolute/path"); err != nil || got != "/absolute/path" {
+		t.Errorf("expected unchanged absolute path, got %q, err %v", got, err)
+	}
+
+	if got, err := ExpandDir(""); err != nil || got != "" {
+		t.Errorf("expected empty string unchanged, got %q, err %v", got, err)
+	}
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// templatesDir returns the directory session templates are loaded from.
+func templatesDir() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".claude-tmux", "sessions")
+}
+
+// Templates loads every *.yml/*.yaml template in the templates directory,
+// expanding each against vars. It returns an empty slice if the directory
+// does not exist. Templates that fail to parse are skipped.
+func Templates(vars map[string]string) ([]*Config, error) {
+	dir := templatesDir()
+	if dir == "" {
+		return nil, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read templates dir: %w", err)
+	}
+
+	var configs []*Config
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		if !strings.HasSuffix(name, ".yml") && !strings.HasSuffix(name, ".yaml") {
+			continue
+		}
+
+		cfg, err := Load(filepath.Join(dir, name), vars)
+		if err != nil {
+			continue
+		}
+		configs = append(configs, cfg)
+	}
+
+	return configs, nil
+}
+
+// FindTemplate returns the template whose Session name matches query, if any.
+func FindTemplate(configs []*Config, query string) (*Config, bool) {
+	for _, cfg := range configs {
+		if cfg.Session == query {
+			return cfg, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTemplate(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write template %s: %v", name, err)
+	}
+}
+
+func TestTemplates(t *testing.T) {
+	tmpHome := t.TempDir()
+	sessionsDir := filepath.Join(tmpHome, ".claude-tmux", "sessions")
+	if err := os.MkdirAll(sessionsDir, 0755); err != nil {
+		t.Fatalf("failed to create sessions dir: %v", err)
+	}
+
+	writeTemplate(t, sessionsDir, "api.yml", "session: api\nroot: /projects/api\n")
+	writeTemplate(t, sessionsDir, "web.yaml", "session: web\nroot: /projects/web\n")
+	writeTemplate(t, sessionsDir, "README.md", "not a template")
+
+	originalHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpHome)
+	defer os.Setenv("HOME", originalHome)
+
+	configs, err := Templates(nil)
+	if err != nil {
+		t.Fatalf("Templates returned error: %v", err)
+	}
+	if len(configs) != 2 {
+		t.Fatalf("expected 2 templates, got %d", len(configs))
+	}
+
+	if _, ok := FindTemplate(configs, "api"); !ok {
+		t.Error("expected to find template 'api'")
+	}
+	if _, ok := FindTemplate(configs, "web"); !ok {
+		t.Error("expected to find template 'web'")
+	}
+}
+
+func TestTemplates_NoDirectory(t *testing.T) {
+	tmpHome := t.TempDir()
+	originalHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpHome)
+	defer os.Setenv("HOME", originalHome)
+
+	configs, err := Templates(nil)
+	if err != nil {
+		t.Fatalf("Templates returned error: %v", err)
+	}
+	if len(configs) != 0 {
+		t.Errorf("expected 0 templates, got %d", len(configs))
+	}
+}
@@ -0,0 +1,148 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ProjectTmux abstracts the tmux operations Up needs. It's similar to Tmux,
+// but SplitWindow also takes the pane's split direction, since ProjectPane
+// (unlike the YAML Pane type Apply consumes) declares it per pane rather
+// than deriving it from a window-wide Layout.
+type ProjectTmux interface {
+	NewSession(name, root string) error
+	NewWindow(session, name, root string) error
+	SplitWindow(target, direction, root string) error
+	SelectLayout(target, layout string) error
+	SendKeys(target, command string) error
+}
+
+// Up creates a tmux session from p: the project's first window becomes the
+// session's initial window, subsequent windows are created in order, and
+// each window's panes are split (in its declared direction) and given their
+// commands before the window's layout (if any) is applied.
+//
+// Up doesn't need to separately "register" the panes it creates: session.Scan
+// and session.MapPanes discover Claude Code sessions by walking real process
+// and tmux pane state, so any pane Up spawns a "claude" command into is
+// picked up by the very next scan, the same as a pane created by hand. See
+// the package doc for how this fits in as a future CLI's entry point.
+func Up(p Project, tmux ProjectTmux) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+
+	dir, err := ExpandDir(p.Dir)
+	if err != nil {
+		return err
+	}
+
+	first := p.Windows[0]
+	if err := tmux.NewSession(p.Name, dir); err != nil {
+		return fmt.Errorf("failed to create session %q: %w", p.Name, err)
+	}
+	if err := upWindow(p, first, dir, tmux); err != nil {
+		return err
+	}
+
+	for _, window := range p.Windows[1:] {
+		if err := tmux.NewWindow(p.Name, window.Name, dir); err != nil {
+			return fmt.Errorf("failed to create window %q: %w", window.Name, err)
+		}
+		if err := upWindow(p, window, dir, tmux); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PlanUp reports the tmux command lines Up would run for p, without running
+// them — the engine behind a "--dry-run" flag on a future "claude-tmux up"
+// subcommand.
+func PlanUp(p Project) ([]string, error) {
+	plan := &planProjectTmux{}
+	if err := Up(p, plan); err != nil {
+		return nil, err
+	}
+	return plan.commands, nil
+}
+
+// planProjectTmux is a ProjectTmux that records the tmux command line each
+// call represents instead of executing it.
+type planProjectTmux struct {
+	commands []string
+}
+
+func (p *planProjectTmux) NewSession(name, root string) error {
+	args := append([]string{"-d", "-s", name}, withRoot(root)...)
+	p.record("new-session", args...)
+	return nil
+}
+
+func (p *planProjectTmux) NewWindow(session, name, root string) error {
+	args := append([]string{"-t", session, "-n", name}, withRoot(root)...)
+	p.record("new-window", args...)
+	return nil
+}
+
+func (p *planProjectTmux) SplitWindow(target, direction, root string) error {
+	args := []string{"split-window", "-t", target}
+	if direction != "" {
+		args = append(args, direction)
+	}
+	args = append(args, withRoot(root)...)
+	p.record(args[0], args[1:]...)
+	return nil
+}
+
+func (p *planProjectTmux) SelectLayout(target, layout string) error {
+	p.record("select-layout", "-t", target, layout)
+	return nil
+}
+
+func (p *planProjectTmux) SendKeys(target, command string) error {
+	p.record("send-keys", "-t", target, command, "Enter")
+	return nil
+}
+
+func (p *planProjectTmux) record(name string, args ...string) {
+	p.commands = append(p.commands, "tmux "+strings.Join(append([]string{name}, args...), " "))
+}
+
+// withRoot returns the "-c root" args to append if root is set, or nil.
+func withRoot(root string) []string {
+	if root == "" {
+		return nil
+	}
+	return []string{"-c", root}
+}
+
+// upWindow splits panes for window (the window itself always starts with one
+// implicit pane, so only panes after the first require a split) and sends
+// each pane's command, then applies the window's layout if declared.
+func upWindow(p Project, window ProjectWindow, dir string, tmux ProjectTmux) error {
+	target := p.Name + ":" + window.Name
+
+	for i, pane := range window.Panes {
+		if i > 0 {
+			if err := tmux.SplitWindow(target, pane.Split, dir); err != nil {
+				return fmt.Errorf("failed to split pane in window %q: %w", window.Name, err)
+			}
+		}
+
+		if pane.Cmd != "" {
+			if err := tmux.SendKeys(target, pane.Cmd); err != nil {
+				return fmt.Errorf("failed to run command in window %q: %w", window.Name, err)
+			}
+		}
+	}
+
+	if window.Layout != "" {
+		if err := tmux.SelectLayout(target, window.Layout); err != nil {
+			return fmt.Errorf("failed to select layout for window %q: %w", window.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+// fakeProjectTmux records the calls Up makes instead of shelling out to tmux.
+type fakeProjectTmux struct {
+	calls []string
+}
+
+func (f *fakeProjectTmux) NewSession(name, root string) error {
+	f.calls = append(f.calls, "new-session "+name+" "+root)
+	return nil
+}
+
+func (f *fakeProjectTmux) NewWindow(session, name, root string) error {
+	f.calls = append(f.calls, "new-window "+session+" "+name+" "+root)
+	return nil
+}
+
+func (f *fakeProjectTmux) SplitWindow(target, direction, root string) error {
+	f.calls = append(f.calls, "split-window "+target+" "+direction+" "+root)
+	return nil
+}
+
+func (f *fakeProjectTmux) SelectLayout(target, layout string) error {
+	f.calls = append(f.calls, "select-layout "+target+" "+layout)
+	return nil
+}
+
+func (f *fakeProjectTmux) SendKeys(target, command string) error {
+	f.calls = append(f.calls, "send-keys "+target+" "+command)
+	return nil
+}
+
+func TestUp(t *testing.T) {
+	p := Project{
+		Name: "api",
+		Dir:  "",
+		Windows: []ProjectWindow{
+			{
+				Name:  "claude",
+				Panes: []ProjectPane{{Cmd: "claude"}},
+			},
+			{
+				Name:   "server",
+				Layout: "main-vertical",
+				Panes: []ProjectPane{
+					{Cmd: "npm run dev"},
+					{Split: "-h", Cmd: "tail -f app.log"},
+				},
+			},
+		},
+	}
+
+	tmux := &fakeProjectTmux{}
+	if err := Up(p, tmux); err != nil {
+		t.Fatalf("Up returned error: %v", err)
+	}
+
+	expected := []string{
+		"new-session api ",
+		"send-keys api:claude claude",
+		"new-window api server ",
+		"send-keys api:server npm run dev",
+		"split-window api:server -h ",
+		"send-keys api:server tail -f app.log",
+		"select-layout api:server main-vertical",
+	}
+	if !reflect.DeepEqual(tmux.calls, expected) {
+		t.Errorf("unexpected call sequence:\n got:  %v\n want: %v", tmux.calls, expected)
+	}
+}
+
+func TestUp_InvalidProjectReturnsErrorWithoutCallingTmux(t *testing.T) {
+	tmux := &fakeProjectTmux{}
+	if err := Up(Project{}, tmux); err == nil {
+		t.Fatal("expected error for project with no name")
+	}
+	if len(tmux.calls) != 0 {
+		t.Errorf("expected no tmux calls, got %v", tmux.calls)
+	}
+}
+
+func TestPlanUp(t *testing.T) {
+	p := Project{
+		Name: "api",
+		Windows: []ProjectWindow{
+			{Name: "claude", Panes: []ProjectPane{{Cmd: "claude"}}},
+		},
+	}
+
+	commands, err := PlanUp(p)
+	if err != nil {
+		t.Fatalf("PlanUp returned error: %v", err)
+	}
+
+	expected := []string{
+		"tmux new-session -d -s api",
+		"tmux send-keys -t api:claude claude Enter",
+	}
+	if !reflect.DeepEqual(commands, expected) {
+		t.Errorf("unexpected plan:\n got:  %v\n want: %v", commands, expected)
+	}
+}
+
+func TestPlanUp_InvalidProjectReturnsError(t *testing.T) {
+	if _, err := PlanUp(Project{}); err == nil {
+		t.Fatal("expected error for project with no name")
+	}
+}
+
+func TestUp_FirstPaneSplitIsIgnored(t *testing.T) {
+	p := Project{
+		Name: "solo",
+		Windows: []ProjectWindow{
+			{Name: "main", Panes: []ProjectPane{{Split: "-v", Cmd: "htop"}}},
+		},
+	}
+
+	tmux := &fakeProjectTmux{}
+	if err := Up(p, tmux); err != nil {
+		t.Fatalf("Up returned error: %v", err)
+	}
+
+	expected := []string{
+		"new-session solo ",
+		"send-keys solo:main htop",
+	}
+	if !reflect.DeepEqual(tmux.calls, expected) {
+		t.Errorf("unexpected call sequence:\n got:  %v\n want: %v", tmux.calls, expected)
+	}
+}
@@ -0,0 +1,24 @@
+package connector
+
+import (
+	"github.com/Jevs21/claude-tmux/internal/config"
+	"github.com/Jevs21/claude-tmux/internal/session"
+)
+
+// ConfigStrategy matches query against the names of the user's saved
+// session templates (see internal/config).
+type ConfigStrategy struct{}
+
+func (ConfigStrategy) Find(query string) (*session.Session, bool, error) {
+	templates, err := config.Templates(nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	cfg, ok := config.FindTemplate(templates, query)
+	if !ok {
+		return nil, false, nil
+	}
+
+	return &session.Session{WorkDir: cfg.Root, ProjectName: cfg.Session}, true, nil
+}
@@ -0,0 +1,49 @@
+package connector
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigStrategy_Find(t *testing.T) {
+	tmpHome := t.TempDir()
+	sessionsDir := filepath.Join(tmpHome, ".claude-tmux", "sessions")
+	if err := os.MkdirAll(sessionsDir, 0755); err != nil {
+		t.Fatalf("failed to create sessions dir: %v", err)
+	}
+	template := "session: api\nroot: /projects/api-server\n"
+	if err := os.WriteFile(filepath.Join(sessionsDir, "api.yml"), []byte(template), 0644); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+
+	originalHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpHome)
+	defer os.Setenv("HOME", originalHome)
+
+	s, ok, err := ConfigStrategy{}.Find("api")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok {
+		t.Fatal("expected a match for template 'api'")
+	}
+	if s.WorkDir != "/projects/api-server" {
+		t.Errorf("expected WorkDir %q, got %q", "/projects/api-server", s.WorkDir)
+	}
+}
+
+func TestConfigStrategy_NoMatch(t *testing.T) {
+	tmpHome := t.TempDir()
+	originalHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpHome)
+	defer os.Setenv("HOME", originalHome)
+
+	_, ok, err := ConfigStrategy{}.Find("nonexistent")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Error("expected no match when no templates exist")
+	}
+}
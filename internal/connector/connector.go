@@ -0,0 +1,66 @@
+package connector
+
+import (
+	"fmt"
+	"os/exec"
+
+	"github.com/Jevs21/claude-tmux/internal/session"
+	"github.com/Jevs21/claude-tmux/internal/tmux"
+)
+
+// strategies are tried in priority order until one resolves the query:
+// exact/fuzzy tmux session match, config alias, zoxide history, literal path.
+var strategies = []Strategy{
+	TmuxStrategy{},
+	ConfigStrategy{},
+	ZoxideStrategy{},
+	DirStrategy{},
+}
+
+// Connect resolves query to a session via the strategy chain, creating a
+// tmux session rooted at the resolved directory if one doesn't already
+// exist, then attaches or switches to it depending on whether the caller is
+// already inside tmux.
+func Connect(query string) error {
+	resolved, err := resolve(query)
+	if err != nil {
+		return err
+	}
+
+	name := resolved.TmuxSession
+	if name == "" {
+		name = session.ShortenPath(resolved.WorkDir)
+		if err := ensureTmuxSession(name, resolved.WorkDir); err != nil {
+			return err
+		}
+	}
+
+	return tmux.Jump(name)
+}
+
+// resolve runs the strategy chain and returns the first match.
+func resolve(query string) (*session.Session, error) {
+	for _, strategy := range strategies {
+		match, ok, err := strategy.Find(query)
+		if err != nil {
+			return nil, fmt.Errorf("strategy lookup failed: %w", err)
+		}
+		if ok {
+			return match, nil
+		}
+	}
+	return nil, fmt.Errorf("no session, config entry, zoxide match, or directory found for %q", query)
+}
+
+// ensureTmuxSession creates a detached tmux session named name rooted at dir,
+// if a session by that name doesn't already exist.
+func ensureTmuxSession(name, dir string) error {
+	if err := exec.Command("tmux", "has-session", "-t", name).Run(); err == nil {
+		return nil
+	}
+
+	if err := exec.Command("tmux", "new-session", "-d", "-s", name, "-c", dir).Run(); err != nil {
+		return fmt.Errorf("failed to create tmux session %q: %w", name, err)
+	}
+	return nil
+}
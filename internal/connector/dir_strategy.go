@@ -0,0 +1,26 @@
+package connector
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/Jevs21/claude-tmux/internal/session"
+)
+
+// DirStrategy treats query as a literal filesystem path. It is the last
+// resort strategy, since almost any string can be statted.
+type DirStrategy struct{}
+
+func (DirStrategy) Find(query string) (*session.Session, bool, error) {
+	info, err := os.Stat(query)
+	if err != nil || !info.IsDir() {
+		return nil, false, nil
+	}
+
+	absDir, err := filepath.Abs(query)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return &session.Session{WorkDir: absDir, ProjectName: filepath.Base(absDir)}, true, nil
+}
@@ -0,0 +1,51 @@
+package connector
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDirStrategy_Find(t *testing.T) {
+	dir := t.TempDir()
+
+	s, ok, err := DirStrategy{}.Find(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok {
+		t.Fatal("expected a match for an existing directory")
+	}
+	if s.WorkDir != dir {
+		t.Errorf("expected WorkDir %q, got %q", dir, s.WorkDir)
+	}
+	if s.ProjectName != filepath.Base(dir) {
+		t.Errorf("expected ProjectName %q, got %q", filepath.Base(dir), s.ProjectName)
+	}
+}
+
+func TestDirStrategy_NoMatchForMissingPath(t *testing.T) {
+	_, ok, err := DirStrategy{}.Find("/definitely/does/not/exist/anywhere")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Error("expected no match for a nonexistent path")
+	}
+}
+
+func TestDirStrategy_NoMatchForFile(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "not-a-dir")
+	if err := os.WriteFile(file, []byte("x"), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	_, ok, err := DirStrategy{}.Find(file)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Error("expected no match for a regular file")
+	}
+}
@@ -0,0 +1,15 @@
+// Package connector resolves a free-form user query (a session name, a
+// config alias, a zoxide hit, or a bare path) into a tmux session to attach
+// to, trying each resolution strategy in priority order.
+package connector
+
+import (
+	"github.com/Jevs21/claude-tmux/internal/session"
+)
+
+// Strategy resolves a query into a session to connect to. The second return
+// value is false (with a nil error) when the strategy simply found no match,
+// so Connect can fall through to the next strategy.
+type Strategy interface {
+	Find(query string) (*session.Session, bool, error)
+}
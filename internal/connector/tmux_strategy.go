@@ -0,0 +1,73 @@
+package connector
+
+import (
+	"bufio"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/Jevs21/claude-tmux/internal/session"
+	"github.com/Jevs21/claude-tmux/internal/tmux"
+)
+
+// TmuxStrategy matches query against the names of existing tmux sessions,
+// first exactly, then as a fuzzy (case-insensitive substring) match.
+type TmuxStrategy struct{}
+
+func (TmuxStrategy) Find(query string) (*session.Session, bool, error) {
+	names, err := tmuxSessionNames()
+	if err != nil {
+		// tmux isn't running, or has no sessions yet — not a Strategy failure.
+		return nil, false, nil
+	}
+
+	for _, name := range names {
+		if name == query {
+			return sessionForTmuxName(name), true, nil
+		}
+	}
+
+	lowerQuery := strings.ToLower(query)
+	for _, name := range names {
+		if strings.Contains(strings.ToLower(name), lowerQuery) {
+			return sessionForTmuxName(name), true, nil
+		}
+	}
+
+	return nil, false, nil
+}
+
+// tmuxSessionNames returns the names of all running tmux sessions.
+func tmuxSessionNames() ([]string, error) {
+	out, err := exec.Command("tmux", "list-sessions", "-F", "#{session_name}").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	scanner := bufio.NewScanner(strings.NewReader(string(out)))
+	for scanner.Scan() {
+		if name := strings.TrimSpace(scanner.Text()); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// sessionForTmuxName builds a minimal Session referencing an existing tmux
+// session by name, targeting its first window and pane. tmux renumbers and
+// gaps window indexes after kills and moves, so the first window is looked
+// up via ListWindows rather than assumed to be index 0.
+func sessionForTmuxName(name string) *session.Session {
+	windows, err := tmux.ListWindows(name)
+	windowIndex := 0
+	if err == nil {
+		windowIndex = tmux.FirstWindowIndex(windows, 0)
+	}
+
+	return &session.Session{
+		TmuxSession: name,
+		TmuxTarget:  fmt.Sprintf("%s:%d.0", name, windowIndex),
+		Windows:     windows,
+	}
+}
@@ -0,0 +1,82 @@
+package connector
+
+import (
+	"os/exec"
+	"testing"
+)
+
+// withTmuxSession creates a detached tmux session for the duration of the
+// test, skipping if tmux isn't available.
+func withTmuxSession(t *testing.T, name string) {
+	t.Helper()
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not available")
+	}
+
+	if err := exec.Command("tmux", "new-session", "-d", "-s", name).Run(); err != nil {
+		t.Skipf("could not start tmux session: %v", err)
+	}
+	t.Cleanup(func() {
+		exec.Command("tmux", "kill-session", "-t", name).Run()
+	})
+}
+
+func TestTmuxStrategy_ExactMatch(t *testing.T) {
+	withTmuxSession(t, "connector-test-exact")
+
+	s, ok, err := TmuxStrategy{}.Find("connector-test-exact")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok {
+		t.Fatal("expected an exact match")
+	}
+	if s.TmuxSession != "connector-test-exact" {
+		t.Errorf("expected TmuxSession %q, got %q", "connector-test-exact", s.TmuxSession)
+	}
+}
+
+func TestTmuxStrategy_FuzzyMatch(t *testing.T) {
+	withTmuxSession(t, "connector-test-fuzzy-session")
+
+	s, ok, err := TmuxStrategy{}.Find("fuzzy")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok {
+		t.Fatal("expected a fuzzy match")
+	}
+	if s.TmuxSession != "connector-test-fuzzy-session" {
+		t.Errorf("expected TmuxSession %q, got %q", "connector-test-fuzzy-session", s.TmuxSession)
+	}
+}
+
+func TestTmuxStrategy_GappedWindowIndex(t *testing.T) {
+	withTmuxSession(t, "connector-test-gapped")
+
+	// Add a second window, then kill the first so the session's only
+	// remaining window has a non-zero index.
+	if err := exec.Command("tmux", "new-window", "-t", "connector-test-gapped").Run(); err != nil {
+		t.Skipf("could not create tmux window: %v", err)
+	}
+	if err := exec.Command("tmux", "kill-window", "-t", "connector-test-gapped:0").Run(); err != nil {
+		t.Skipf("could not kill tmux window: %v", err)
+	}
+
+	s := sessionForTmuxName("connector-test-gapped")
+	if s.TmuxTarget == "connector-test-gapped:0.0" {
+		t.Errorf("expected TmuxTarget to reflect the surviving window, not a hardcoded window 0, got %q", s.TmuxTarget)
+	}
+}
+
+func TestTmuxStrategy_NoMatch(t *testing.T) {
+	withTmuxSession(t, "connector-test-nomatch")
+
+	_, ok, err := TmuxStrategy{}.Find("totally-unrelated-query")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Error("expected no match for an unrelated query")
+	}
+}
@@ -0,0 +1,34 @@
+package connector
+
+import (
+	"bufio"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/Jevs21/claude-tmux/internal/session"
+)
+
+// ZoxideStrategy resolves query against the zoxide directory database,
+// picking the highest-frecency match.
+type ZoxideStrategy struct{}
+
+func (ZoxideStrategy) Find(query string) (*session.Session, bool, error) {
+	out, err := exec.Command("zoxide", "query", "-l", query).Output()
+	if err != nil {
+		// zoxide isn't installed, or has no match — not a Strategy failure.
+		return nil, false, nil
+	}
+
+	// zoxide query -l lists matches ordered by frecency, highest first.
+	scanner := bufio.NewScanner(strings.NewReader(string(out)))
+	for scanner.Scan() {
+		dir := strings.TrimSpace(scanner.Text())
+		if dir == "" {
+			continue
+		}
+		return &session.Session{WorkDir: dir, ProjectName: filepath.Base(dir)}, true, nil
+	}
+
+	return nil, false, nil
+}
@@ -0,0 +1,52 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// AppendEvent appends event as a JSON line to the calling process's own
+// shard (see eventShardPath), creating it if this is the process's first
+// event. See the package doc for how this fits in as a future CLI's (or
+// the Claude Code hook shim's) entry point.
+//
+// Each event goes out as one write on a file opened with O_APPEND. The
+// shared flock AppendEvent holds around that write doesn't exclude other
+// appenders; it keeps an append from landing between rotateShard's
+// trailing-line check and its rename of the same file: the rotator takes an
+// exclusive lock for that span and waits for every in-flight append to
+// release its shared one first.
+func AppendEvent(event RawEvent) error {
+	path, err := eventShardPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open event shard %q: %w", path, err)
+	}
+	defer file.Close()
+
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_SH); err != nil {
+		return fmt.Errorf("failed to lock event shard %q: %w", path, err)
+	}
+	defer syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	line = append(line, '\n')
+
+	if _, err := file.Write(line); err != nil {
+		return fmt.Errorf("failed to append event to shard %q: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,472 @@
+package session
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// RotationPolicy controls how RotateLogWithPolicy archives an event shard
+// once it grows too large.
+type RotationPolicy struct {
+	// MaxBytes is the shard size, in bytes, that triggers rotation.
+	MaxBytes int64
+	// MaxLines, if nonzero, additionally triggers rotation once the shard's
+	// line count reaches it, regardless of MaxBytes — useful for shards of
+	// many small lines that would otherwise grow for a long time before
+	// crossing a byte threshold.
+	MaxLines int
+	// MaxArchives is how many rotated generations to keep per shard. The
+	// oldest is deleted once a rotation would exceed it.
+	MaxArchives int
+	// Compress gzips archives beyond the most recent generation (".1").
+	Compress bool
+	// MaxTotalBytes, if nonzero, additionally prunes a shard's oldest
+	// archives (highest generation number first) until its archives' combined
+	// size no longer exceeds it, regardless of MaxArchives.
+	MaxTotalBytes int64
+	// MaxAge, if nonzero, additionally prunes any archive whose rotation time
+	// (its file's mtime) is older than this, regardless of MaxArchives.
+	MaxAge time.Duration
+	// CompressLevel is passed to gzip when Compress is set. Zero means
+	// gzip.DefaultCompression.
+	CompressLevel int
+	// VerifyChecksum writes a CRC32C checksum sidecar alongside each newly
+	// rotated archive, so VerifySegments can later detect corruption.
+	VerifyChecksum bool
+}
+
+// DefaultRotationPolicy is the policy RotateLog uses: rotate at 1 MiB, keep
+// 5 generations, and gzip everything older than the newest one.
+func DefaultRotationPolicy() RotationPolicy {
+	return RotationPolicy{MaxBytes: 1 << 20, MaxArchives: 5, Compress: true}
+}
+
+// RotateLog rotates every event log shard that has grown past the default
+// size threshold. Called on startup to prevent unbounded log growth.
+func RotateLog() error {
+	return RotateLogWithPolicy(DefaultRotationPolicy())
+}
+
+// RotateLogWithPolicy rotates every event log shard that has grown past
+// policy's size threshold. Shards are rotated independently: a busy shard
+// doesn't delay rotation of its quieter siblings.
+func RotateLogWithPolicy(policy RotationPolicy) error {
+	paths, err := shardPaths()
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		if err := rotateShard(path, policy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rotateShard archives path and starts a fresh, empty replacement if path
+// has grown past policy.MaxBytes.
+func rotateShard(path string, policy RotationPolicy) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to stat event shard %q for rotation: %w", path, err)
+	}
+	trigger := info.Size() >= policy.MaxBytes
+	if !trigger && policy.MaxLines > 0 {
+		lines, err := countLines(path)
+		if err != nil {
+			return fmt.Errorf("failed to count lines in event shard %q: %w", path, err)
+		}
+		trigger = lines >= policy.MaxLines
+	}
+	if !trigger {
+		return nil
+	}
+
+	// Hold an exclusive lock on the shard for the rest of rotation, so it
+	// can't rename the file out from under an AppendEvent call that's
+	// mid-write (AppendEvent holds a shared lock for the same span).
+	lockFile, err := os.OpenFile(path, os.O_RDWR, 0644)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to open event shard %q for rotation: %w", path, err)
+	}
+	defer lockFile.Close()
+	if err := syscall.Flock(int(lockFile.Fd()), syscall.LOCK_EX); err != nil {
+		return fmt.Errorf("failed to lock event shard %q for rotation: %w", path, err)
+	}
+	defer syscall.Flock(int(lockFile.Fd()), syscall.LOCK_UN)
+
+	// A writer caught mid-append leaves a trailing line with no newline yet;
+	// rotating now would split that JSON record across the old and new
+	// segments. Defer rotation to the next cycle instead. This still guards
+	// against non-locking writers (e.g. the hook shell script), which don't
+	// participate in the flock protocol above.
+	unterminated, err := hasUnterminatedTrailingLine(path)
+	if err != nil {
+		return fmt.Errorf("failed to check trailing line of event shard %q: %w", path, err)
+	}
+	if unterminated {
+		return nil
+	}
+
+	if err := shiftArchives(path, policy); err != nil {
+		return err
+	}
+
+	firstArchive := archivePath(path, 1, policy.Compress)
+	if err := os.Rename(path, firstArchive); err != nil {
+		return fmt.Errorf("failed to archive event shard %q: %w", path, err)
+	}
+	if policy.VerifyChecksum {
+		if err := writeChecksumSidecar(firstArchive); err != nil {
+			return err
+		}
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to start fresh event shard %q: %w", path, err)
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return pruneRetention(path, policy)
+}
+
+// hasUnterminatedTrailingLine reports whether path's last byte isn't a
+// newline — evidence a writer is still mid-append on its final line.
+func hasUnterminatedTrailingLine(path string) (bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return false, err
+	}
+	if info.Size() == 0 {
+		return false, nil
+	}
+
+	buf := make([]byte, 1)
+	if _, err := file.ReadAt(buf, info.Size()-1); err != nil {
+		return false, err
+	}
+	return buf[0] != '\n', nil
+}
+
+// countLines returns the number of newline-terminated lines in path,
+// streaming through it with a bounded buffer rather than reading it into
+// memory all at once.
+func countLines(path string) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1<<20)
+	count := 0
+	for scanner.Scan() {
+		count++
+	}
+	return count, scanner.Err()
+}
+
+// pruneRetention deletes path's oldest archives (highest generation number
+// first) beyond what policy.MaxTotalBytes and policy.MaxAge allow, on top of
+// whatever shiftArchives already enforced for MaxArchives. Either limit left
+// at zero is treated as unbounded.
+func pruneRetention(path string, policy RotationPolicy) error {
+	if policy.MaxTotalBytes <= 0 && policy.MaxAge <= 0 {
+		return nil
+	}
+
+	archives := listArchives(path) // oldest (highest generation) first
+	var total int64
+	sizes := make(map[string]int64, len(archives))
+	for _, archive := range archives {
+		info, err := os.Stat(archive)
+		if err != nil {
+			continue
+		}
+		sizes[archive] = info.Size()
+		total += info.Size()
+	}
+
+	cutoff := time.Time{}
+	if policy.MaxAge > 0 {
+		cutoff = time.Now().Add(-policy.MaxAge)
+	}
+
+	for _, archive := range archives {
+		size, ok := sizes[archive]
+		if !ok {
+			continue
+		}
+
+		overBytes := policy.MaxTotalBytes > 0 && total > policy.MaxTotalBytes
+		overAge := false
+		if policy.MaxAge > 0 {
+			if info, err := os.Stat(archive); err == nil {
+				overAge = info.ModTime().Before(cutoff)
+			}
+		}
+		if !overBytes && !overAge {
+			continue
+		}
+
+		if err := os.Remove(archive); err != nil {
+			return fmt.Errorf("failed to prune archive %q past retention: %w", archive, err)
+		}
+		if err := removeChecksumSidecar(archive); err != nil {
+			return err
+		}
+		total -= size
+	}
+
+	return nil
+}
+
+// archivePath builds the archive filename for path's Nth-oldest generation.
+// Generation 1 (the most recently rotated-out) is never compressed, so a
+// just-rotated shard's newest history is always readable without gzip.
+func archivePath(path string, n int, compress bool) string {
+	p := fmt.Sprintf("%s.%d", path, n)
+	if n > 1 && compress {
+		p += ".gz"
+	}
+	return p
+}
+
+// shiftArchives makes room for a new generation-1 archive: the oldest
+// generation beyond policy.MaxArchives is deleted, and every remaining
+// generation is renamed up by one. Generation 1 becomes generation 2 in the
+// process, and is gzip-compressed if policy.Compress is set, since only
+// generation 1 is ever kept uncompressed.
+func shiftArchives(path string, policy RotationPolicy) error {
+	maxN := policy.MaxArchives
+	if maxN < 1 {
+		maxN = 1
+	}
+
+	oldest := archivePath(path, maxN, policy.Compress)
+	if _, err := os.Stat(oldest); err == nil {
+		if err := os.Remove(oldest); err != nil {
+			return fmt.Errorf("failed to prune oldest archive %q: %w", oldest, err)
+		}
+		if err := removeChecksumSidecar(oldest); err != nil {
+			return err
+		}
+	}
+
+	for n := maxN - 1; n >= 1; n-- {
+		src := archivePath(path, n, policy.Compress)
+		if _, err := os.Stat(src); err != nil {
+			continue
+		}
+		dst := archivePath(path, n+1, policy.Compress)
+
+		if n == 1 && policy.Compress {
+			if err := compressFile(src, dst, policy.CompressLevel); err != nil {
+				return fmt.Errorf("failed to compress archive %q: %w", src, err)
+			}
+			// The checksum is taken over decompressed content, so it's still
+			// valid once src becomes gzipped as dst — just carry it along.
+			if err := moveChecksumSidecar(src, dst); err != nil {
+				return err
+			}
+			if err := os.Remove(src); err != nil {
+				return fmt.Errorf("failed to remove archive %q after compressing: %w", src, err)
+			}
+			continue
+		}
+
+		if err := os.Rename(src, dst); err != nil {
+			return fmt.Errorf("failed to shift archive %q to %q: %w", src, dst, err)
+		}
+		if err := moveChecksumSidecar(src, dst); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compressFile gzips src's content into dst at the given level (zero means
+// gzip.DefaultCompression).
+func compressFile(src, dst string, level int) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	if level == 0 {
+		level = gzip.DefaultCompression
+	}
+	gz, err := gzip.NewWriterLevel(out, level)
+	if err != nil {
+		return fmt.Errorf("invalid gzip compression level %d: %w", level, err)
+	}
+	if _, err := io.Copy(gz, in); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// archiveSuffix matches the generation number a rotated archive's filename
+// ends in, e.g. ".3" or ".3.gz".
+var archiveSuffix = regexp.MustCompile(`\.(\d+)(\.gz)?$`)
+
+// listArchives returns path's existing rotated archives, oldest (highest
+// generation number) first — the order their events happened in.
+func listArchives(path string) []string {
+	matches, err := filepath.Glob(path + ".*")
+	if err != nil {
+		return nil
+	}
+
+	type generation struct {
+		path string
+		n    int
+	}
+	var found []generation
+	for _, m := range matches {
+		suffix := strings.TrimPrefix(m, path)
+		sub := archiveSuffix.FindStringSubmatch(suffix)
+		if sub == nil {
+			continue
+		}
+		n, err := strconv.Atoi(sub[1])
+		if err != nil {
+			continue
+		}
+		found = append(found, generation{path: m, n: n})
+	}
+
+	sort.Slice(found, func(i, j int) bool { return found[i].n > found[j].n })
+
+	paths := make([]string, len(found))
+	for i, g := range found {
+		paths[i] = g.path
+	}
+	return paths
+}
+
+// readArchiveEvents decodes an archive's JSONL events via SegmentReader,
+// transparently gunzipping if its name ends in ".gz". Unreadable or
+// malformed archives are skipped rather than failing the whole read.
+func readArchiveEvents(path string) []RawEvent {
+	reader, err := OpenSegmentReader(path)
+	if err != nil {
+		return nil
+	}
+	defer reader.Close()
+
+	var events []RawEvent
+	for {
+		event, ok := reader.Next()
+		if !ok {
+			break
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// shardEventsWithHistory returns a shard's full chronological event stream,
+// including every rotated archive. Archives are read oldest generation
+// first, the same order rotation pushed events through them, followed by
+// the live file — so a shard that has been rotated since it was last read
+// still contributes its pre-rotation history instead of a session simply
+// vanishing the moment its shard rotates out from under it.
+func shardEventsWithHistory(path string) []RawEvent {
+	return shardEventsWithHistoryN(path, 0)
+}
+
+// shardEventsWithHistoryN is shardEventsWithHistory bounded to at most the n
+// most recently rotated archive generations (0 means unbounded), so a
+// caller that only needs to recover recently-scrolled-out sessions isn't
+// forced to decompress and scan a shard's entire archive chain every time.
+func shardEventsWithHistoryN(path string, n int) []RawEvent {
+	archives := listArchives(path) // oldest generation first, ending with the most recent
+	if n > 0 && len(archives) > n {
+		archives = archives[len(archives)-n:]
+	}
+
+	var events []RawEvent
+	for _, archive := range archives {
+		events = append(events, readArchiveEvents(archive)...)
+	}
+
+	reader, err := openShardReader(path)
+	if err != nil {
+		return events
+	}
+	defer reader.close()
+	for {
+		event, ok := reader.next()
+		if !ok {
+			break
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// mergeEventsByPath combines each shard path's already-chronological event
+// slice into one global stream ordered by timestamp, tie-broken by path —
+// the same tie-break mergeShardEvents' heap uses — for determinism when
+// several shards report an event at the same second.
+func mergeEventsByPath(byPath map[string][]RawEvent) []RawEvent {
+	type pathedEvent struct {
+		event RawEvent
+		path  string
+	}
+
+	var all []pathedEvent
+	for path, events := range byPath {
+		for _, event := range events {
+			all = append(all, pathedEvent{event: event, path: path})
+		}
+	}
+
+	sort.SliceStable(all, func(i, j int) bool {
+		if all[i].event.Timestamp != all[j].event.Timestamp {
+			return all[i].event.Timestamp < all[j].event.Timestamp
+		}
+		return all[i].path < all[j].path
+	})
+
+	merged := make([]RawEvent, len(all))
+	for i, pe := range all {
+		merged[i] = pe.event
+	}
+	return merged
+}
@@ -0,0 +1,54 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// HasTmuxSession reports whether a tmux session with the given name exists.
+func HasTmuxSession(name string) bool {
+	return tmuxSessionExists(name)
+}
+
+// Attach attaches to s's tmux session (using switch-client instead when
+// already inside tmux, detected via $TMUX, mirroring tmux.Jump), then
+// selects its window and pane so the client lands exactly where s was
+// captured rather than wherever that session's attach point happens to be.
+func Attach(s Session) error {
+	verb := "attach-session"
+	if os.Getenv("TMUX") != "" {
+		verb = "switch-client"
+	}
+	return jumpTo(s, verb)
+}
+
+// SwitchClient is like Attach, but always runs switch-client, for callers
+// that already know they're inside tmux.
+func SwitchClient(s Session) error {
+	return jumpTo(s, "switch-client")
+}
+
+// jumpTo runs verb ("attach-session" or "switch-client") against s's tmux
+// session, then selects its window and pane.
+func jumpTo(s Session, verb string) error {
+	if s.TmuxSession == "" {
+		return fmt.Errorf("session %q has no tmux session to attach to", s.SessionID)
+	}
+
+	if err := ActiveCommander.ExecSilently(exec.Command("tmux", verb, "-t", s.TmuxSession)); err != nil {
+		return fmt.Errorf("failed to %s to tmux session %q: %w", verb, s.TmuxSession, err)
+	}
+
+	window := fmt.Sprintf("%s:%d", s.TmuxSession, s.WindowIndex)
+	if err := ActiveCommander.ExecSilently(exec.Command("tmux", "select-window", "-t", window)); err != nil {
+		return fmt.Errorf("failed to select window %q: %w", window, err)
+	}
+
+	pane := fmt.Sprintf("%s:%d.%d", s.TmuxSession, s.WindowIndex, s.PaneIndex)
+	if err := ActiveCommander.ExecSilently(exec.Command("tmux", "select-pane", "-t", pane)); err != nil {
+		return fmt.Errorf("failed to select pane %q: %w", pane, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package session
+
+import (
+	"os"
+	"testing"
+)
+
+func withTmuxEnv(t *testing.T, value string) {
+	t.Helper()
+	old, had := os.LookupEnv("TMUX")
+	if value == "" {
+		os.Unsetenv("TMUX")
+	} else {
+		os.Setenv("TMUX", value)
+	}
+	t.Cleanup(func() {
+		if had {
+			os.Setenv("TMUX", old)
+		} else {
+			os.Unsetenv("TMUX")
+		}
+	})
+}
+
+func TestAttach_OutsideTmux_UsesAttachSession(t *testing.T) {
+	withTmuxEnv(t, "")
+	fake := &fakeCommander{output: map[string]string{}}
+	old := ActiveCommander
+	ActiveCommander = fake
+	defer func() { ActiveCommander = old }()
+
+	s := Session{SessionID: "s1", TmuxSession: "mywork", WindowIndex: 3, PaneIndex: 1}
+	if err := Attach(s); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantCalls := []string{
+		"tmux attach-session -t mywork",
+		"tmux select-window -t mywork:3",
+		"tmux select-pane -t mywork:3.1",
+	}
+	if len(fake.calls) != len(wantCalls) {
+		t.Fatalf("expected calls %v, got %v", wantCalls, fake.calls)
+	}
+	for i, want := range wantCalls {
+		if fake.calls[i] != want {
+			t.Errorf("call %d: expected %q, got %q", i, want, fake.calls[i])
+		}
+	}
+}
+
+func TestAttach_InsideTmux_UsesSwitchClient(t *testing.T) {
+	withTmuxEnv(t, "/tmp/tmux-1000/default,1234,0")
+	fake := &fakeCommander{output: map[string]string{}}
+	old := ActiveCommander
+	ActiveCommander = fake
+	defer func() { ActiveCommander = old }()
+
+	s := Session{SessionID: "s1", TmuxSession: "mywork", WindowIndex: 3, PaneIndex: 1}
+	if err := Attach(s); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "tmux switch-client -t mywork"
+	if len(fake.calls) == 0 || fake.calls[0] != want {
+		t.Errorf("expected first call %q, got %v", want, fake.calls)
+	}
+}
+
+func TestSwitchClient_AlwaysUsesSwitchClient(t *testing.T) {
+	withTmuxEnv(t, "")
+	fake := &fakeCommander{output: map[string]string{}}
+	old := ActiveCommander
+	ActiveCommander = fake
+	defer func() { ActiveCommander = old }()
+
+	s := Session{SessionID: "s1", TmuxSession: "mywork", WindowIndex: 3, PaneIndex: 1}
+	if err := SwitchClient(s); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantCalls := []string{
+		"tmux switch-client -t mywork",
+		"tmux select-window -t mywork:3",
+		"tmux select-pane -t mywork:3.1",
+	}
+	if len(fake.calls) != len(wantCalls) {
+		t.Fatalf("expected calls %v, got %v", wantCalls, fake.calls)
+	}
+	for i, want := range wantCalls {
+		if fake.calls[i] != want {
+			t.Errorf("call %d: expected %q, got %q", i, want, fake.calls[i])
+		}
+	}
+}
+
+func TestAttach_NoTmuxSessionReturnsError(t *testing.T) {
+	fake := &fakeCommander{output: map[string]string{}}
+	old := ActiveCommander
+	ActiveCommander = fake
+	defer func() { ActiveCommander = old }()
+
+	if err := Attach(Session{SessionID: "detached"}); err == nil {
+		t.Fatal("expected an error attaching to a session with no tmux target")
+	}
+	if len(fake.calls) != 0 {
+		t.Errorf("expected no tmux calls for a detached session, got %v", fake.calls)
+	}
+}
+
+func TestHasTmuxSession(t *testing.T) {
+	fake := &fakeCommander{output: map[string]string{}, failFor: map[string]bool{
+		"tmux has-session -t missing": true,
+	}}
+	old := ActiveCommander
+	ActiveCommander = fake
+	defer func() { ActiveCommander = old }()
+
+	if !HasTmuxSession("mywork") {
+		t.Error("expected mywork to be reported as existing")
+	}
+	if HasTmuxSession("missing") {
+		t.Error("expected missing to be reported as not existing")
+	}
+}
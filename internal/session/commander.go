@@ -0,0 +1,8 @@
+package session
+
+import "github.com/Jevs21/claude-tmux/internal/tmux"
+
+// ActiveCommander runs every external command this package shells out to
+// (git/hg/jj, and tmux itself). Tests substitute a fake to assert
+// the exact command strings issued without invoking real binaries.
+var ActiveCommander tmux.Commander = tmux.NewDefaultCommander(nil)
@@ -0,0 +1,93 @@
+package session
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/Jevs21/claude-tmux/internal/tmux"
+)
+
+// fakeCommander records every command issued to it instead of running
+// anything for real, and returns canned output keyed by the joined argv.
+// Entries in failFor make the matching argv return an error, as a stand-in
+// for the command exiting non-zero (e.g. "tmux has-session" for a session
+// that doesn't exist).
+type fakeCommander struct {
+	calls   []string
+	output  map[string]string
+	failFor map[string]bool
+}
+
+func (f *fakeCommander) Exec(cmd *exec.Cmd) (string, error) {
+	argv := strings.Join(cmd.Args, " ")
+	f.calls = append(f.calls, argv)
+	if f.failFor[argv] {
+		return "", fmt.Errorf("fakeCommander: %q failed", argv)
+	}
+	return f.output[argv], nil
+}
+
+func (f *fakeCommander) ExecSilently(cmd *exec.Cmd) error {
+	argv := strings.Join(cmd.Args, " ")
+	f.calls = append(f.calls, argv)
+	if f.failFor[argv] {
+		return fmt.Errorf("fakeCommander: %q failed", argv)
+	}
+	return nil
+}
+
+var _ tmux.Commander = (*fakeCommander)(nil)
+
+func TestGitVCS_CurrentWorkUnit_UsesFakeCommander(t *testing.T) {
+	fake := &fakeCommander{output: map[string]string{
+		"git -C /tmp/myrepo symbolic-ref --short -q HEAD": "feature/foo\n",
+	}}
+	old := ActiveCommander
+	ActiveCommander = fake
+	defer func() { ActiveCommander = old }()
+
+	unit, err := gitVCS{}.currentWorkUnit("/tmp/myrepo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if unit != "feature/foo" {
+		t.Errorf("expected %q, got %q", "feature/foo", unit)
+	}
+
+	want := "git -C /tmp/myrepo symbolic-ref --short -q HEAD"
+	if len(fake.calls) != 1 || fake.calls[0] != want {
+		t.Errorf("expected exact call %q, got %v", want, fake.calls)
+	}
+}
+
+func TestExistingSessionRepoRoot_UsesFakeCommander(t *testing.T) {
+	fake := &fakeCommander{output: map[string]string{
+		"tmux show-options -t myrepo/main -qv @claude_tmux_repo_root": "/tmp/myrepo\n",
+	}}
+	old := ActiveCommander
+	ActiveCommander = fake
+	defer func() { ActiveCommander = old }()
+
+	root, exists := existingSessionRepoRoot("myrepo/main")
+	if !exists {
+		t.Fatal("expected existing session to be reported")
+	}
+	if root != "/tmp/myrepo" {
+		t.Errorf("expected %q, got %q", "/tmp/myrepo", root)
+	}
+
+	wantCalls := []string{
+		"tmux has-session -t myrepo/main",
+		"tmux show-options -t myrepo/main -qv @claude_tmux_repo_root",
+	}
+	if len(fake.calls) != len(wantCalls) {
+		t.Fatalf("expected calls %v, got %v", wantCalls, fake.calls)
+	}
+	for i, want := range wantCalls {
+		if fake.calls[i] != want {
+			t.Errorf("call %d: expected %q, got %q", i, want, fake.calls[i])
+		}
+	}
+}
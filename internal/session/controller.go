@@ -0,0 +1,35 @@
+package session
+
+import "github.com/Jevs21/claude-tmux/internal/tmux"
+
+// ActiveController is the optional tmux control-mode client getTmuxPanes and
+// capturePaneContent submit commands through. It's nil by default, which
+// keeps this package on the one-shot exec path through ActiveCommander;
+// callers that want control mode should construct a *tmux.Controller (e.g.
+// via tmux.NewController) and assign it here once at startup.
+var ActiveController *tmux.Controller
+
+// WatchControllerNotifications relays ActiveController's pane/window/session
+// notifications as rescan signals, coalesced the same way WatchForChanges
+// coalesces hook events — callers only need to know something changed, not
+// what or how many times. It returns nil if ActiveController is unset, so a
+// select can read from it unconditionally (a nil channel just never fires).
+// The returned channel closes once ActiveController gives up and its own
+// Notifications channel closes.
+func WatchControllerNotifications() <-chan struct{} {
+	if ActiveController == nil {
+		return nil
+	}
+
+	changed := make(chan struct{}, 1)
+	go func() {
+		defer close(changed)
+		for range ActiveController.Notifications() {
+			select {
+			case changed <- struct{}{}:
+			default:
+			}
+		}
+	}()
+	return changed
+}
@@ -0,0 +1,181 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Jevs21/claude-tmux/internal/tmux"
+)
+
+// controlClient is the subset of *tmux.Controller's API Watch needs. It's an
+// interface, rather than Watch taking *tmux.Controller directly, purely so
+// tests can drive the notification state machine below without a real tmux
+// control connection; *tmux.Controller satisfies it as-is.
+type controlClient interface {
+	Command(cmdLine string) ([]string, error)
+	Notifications() <-chan tmux.Notification
+}
+
+// paneTarget is what a raw tmux pane/window ID resolves to: the
+// "session:window.pane" string Session.TmuxTarget uses, plus the window ID
+// it belongs to, so a window-level notification can be matched against
+// every pane underneath it.
+type paneTarget struct {
+	windowID string
+	target   string
+}
+
+// queryPaneTargets asks client for every pane currently known to the tmux
+// server and returns an index from pane ID (e.g. "%3") to its paneTarget.
+// Watch calls this once at startup; a notification naming a pane or window
+// ID that isn't in the index (e.g. because it was created after the last
+// refresh) is simply ignored, same as any other best-effort tmux query in
+// this package.
+func queryPaneTargets(client controlClient) map[string]paneTarget {
+	lines, err := client.Command(`list-panes -a -F "#{pane_id} #{window_id} #{session_name}:#{window_index}.#{pane_index}"`)
+	if err != nil {
+		return nil
+	}
+
+	index := make(map[string]paneTarget, len(lines))
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			continue
+		}
+		index[fields[0]] = paneTarget{windowID: fields[1], target: fields[2]}
+	}
+	return index
+}
+
+// applyNotification updates sessionMap (keyed by SessionID) in response to a
+// single control-mode notification, returning true if it changed anything.
+//
+// window-close and pane-exited evict the affected session immediately,
+// bypassing the PID liveness check ReadSessions relies on — the pane is
+// gone, so there's nothing left to poll. session-renamed updates
+// TmuxSession (and the TmuxTarget string derived from it) for every session
+// attached to the renamed tmux session.
+func applyNotification(sessionMap map[string]Session, paneIndex map[string]paneTarget, notification tmux.Notification) bool {
+	switch notification.Name {
+	case "pane-exited":
+		if len(notification.Args) == 0 {
+			return false
+		}
+		pt, ok := paneIndex[notification.Args[0]]
+		if !ok {
+			return false
+		}
+		return evictByTarget(sessionMap, pt.target)
+
+	case "window-close", "unlinked-window-close":
+		if len(notification.Args) == 0 {
+			return false
+		}
+		windowID := notification.Args[0]
+		changed := false
+		for id, pt := range paneIndex {
+			if pt.windowID != windowID {
+				continue
+			}
+			if evictByTarget(sessionMap, pt.target) {
+				changed = true
+			}
+			delete(paneIndex, id)
+		}
+		return changed
+
+	case "session-renamed":
+		if len(notification.Args) != 2 {
+			return false
+		}
+		oldName, newName := notification.Args[0], notification.Args[1]
+		changed := false
+		for id, s := range sessionMap {
+			if s.TmuxSession != oldName {
+				continue
+			}
+			s.TmuxSession = newName
+			if s.TmuxTarget != "" {
+				_, window, pane := parseTmuxTarget(s.TmuxTarget)
+				s.TmuxTarget = renderTmuxTarget(newName, window, pane)
+			}
+			sessionMap[id] = s
+			changed = true
+		}
+		for id, pt := range paneIndex {
+			if sessionName, _, _ := parseTmuxTarget(pt.target); sessionName == oldName {
+				_, window, pane := parseTmuxTarget(pt.target)
+				pt.target = renderTmuxTarget(newName, window, pane)
+				paneIndex[id] = pt
+			}
+		}
+		return changed
+	}
+	return false
+}
+
+// evictByTarget removes the session (if any) whose TmuxTarget matches
+// target, reporting whether a session was actually removed.
+func evictByTarget(sessionMap map[string]Session, target string) bool {
+	for id, s := range sessionMap {
+		if s.TmuxTarget == target {
+			delete(sessionMap, id)
+			return true
+		}
+	}
+	return false
+}
+
+// renderTmuxTarget is the inverse of parseTmuxTarget.
+func renderTmuxTarget(sessionName string, window, pane int) string {
+	return fmt.Sprintf("%s:%d.%d", sessionName, window, pane)
+}
+
+// Watch applies client's control-mode notification stream on top of
+// initial (typically ReadSessions' last result), pushing the updated
+// session slice to updates every time a notification changes it. It blocks
+// until ctx is canceled (returning ctx.Err()) or client's Notifications
+// channel closes because the control connection gave up reconnecting
+// (returning nil). The UI can subscribe to updates instead of re-reading
+// the event log on every tick — Watch only ever reacts to pane/window/
+// session lifecycle notifications, so a brand new session-start still has
+// to arrive through the event log the usual way.
+func Watch(ctx context.Context, controller *tmux.Controller, initial []Session, updates chan<- []Session) error {
+	return watch(ctx, controller, initial, updates)
+}
+
+func watch(ctx context.Context, client controlClient, initial []Session, updates chan<- []Session) error {
+	sessionMap := make(map[string]Session, len(initial))
+	for _, s := range initial {
+		sessionMap[s.SessionID] = s
+	}
+	paneIndex := queryPaneTargets(client)
+
+	notifications := client.Notifications()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case notification, ok := <-notifications:
+			if !ok {
+				return nil
+			}
+			if applyNotification(sessionMap, paneIndex, notification) {
+				updates <- sessionSlice(sessionMap)
+			}
+		}
+	}
+}
+
+// sessionSlice flattens sessionMap into a slice, sorted the same way
+// ReadSessions' result is.
+func sessionSlice(sessionMap map[string]Session) []Session {
+	sessions := make([]Session, 0, len(sessionMap))
+	for _, s := range sessionMap {
+		sessions = append(sessions, s)
+	}
+	SortSessions(sessions)
+	return sessions
+}
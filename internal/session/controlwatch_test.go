@@ -0,0 +1,172 @@
+package session
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Jevs21/claude-tmux/internal/tmux"
+)
+
+// fakeControlClient is a minimal controlClient stand-in: Command returns a
+// canned response, Notifications replays a fixed, pre-loaded sequence.
+type fakeControlClient struct {
+	paneLines     []string
+	notifications chan tmux.Notification
+}
+
+func newFakeControlClient(paneLines []string) *fakeControlClient {
+	return &fakeControlClient{
+		paneLines:     paneLines,
+		notifications: make(chan tmux.Notification, 8),
+	}
+}
+
+func (f *fakeControlClient) Command(cmdLine string) ([]string, error) {
+	return f.paneLines, nil
+}
+
+func (f *fakeControlClient) Notifications() <-chan tmux.Notification {
+	return f.notifications
+}
+
+func (f *fakeControlClient) push(n tmux.Notification) {
+	f.notifications <- n
+}
+
+func waitForUpdate(t *testing.T, updates <-chan []Session) []Session {
+	t.Helper()
+	select {
+	case sessions := <-updates:
+		return sessions
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for an update")
+		return nil
+	}
+}
+
+func TestWatch_PaneExitedEvictsSession(t *testing.T) {
+	client := newFakeControlClient([]string{"%1 @1 work:0.0"})
+	initial := []Session{{SessionID: "s1", TmuxTarget: "work:0.0", TmuxSession: "work"}}
+	updates := make(chan []Session, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- watch(ctx, client, initial, updates) }()
+
+	client.push(tmux.Notification{Name: "pane-exited", Args: []string{"%1"}})
+
+	sessions := waitForUpdate(t, updates)
+	if len(sessions) != 0 {
+		t.Errorf("expected the exited pane's session to be evicted, got %+v", sessions)
+	}
+
+	cancel()
+	<-done
+}
+
+func TestWatch_WindowCloseEvictsEveryPaneInWindow(t *testing.T) {
+	client := newFakeControlClient([]string{"%1 @1 work:0.0", "%2 @1 work:0.1"})
+	initial := []Session{
+		{SessionID: "s1", TmuxTarget: "work:0.0", TmuxSession: "work"},
+		{SessionID: "s2", TmuxTarget: "work:0.1", TmuxSession: "work"},
+	}
+	updates := make(chan []Session, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- watch(ctx, client, initial, updates) }()
+
+	client.push(tmux.Notification{Name: "window-close", Args: []string{"@1"}})
+
+	sessions := waitForUpdate(t, updates)
+	if len(sessions) != 0 {
+		t.Errorf("expected both panes' sessions to be evicted, got %+v", sessions)
+	}
+
+	cancel()
+	<-done
+}
+
+func TestWatch_SessionRenamedUpdatesTmuxSessionAndTarget(t *testing.T) {
+	client := newFakeControlClient([]string{"%1 @1 work:0.0"})
+	initial := []Session{{SessionID: "s1", TmuxTarget: "work:0.0", TmuxSession: "work"}}
+	updates := make(chan []Session, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- watch(ctx, client, initial, updates) }()
+
+	client.push(tmux.Notification{Name: "session-renamed", Args: []string{"work", "project"}})
+
+	sessions := waitForUpdate(t, updates)
+	if len(sessions) != 1 {
+		t.Fatalf("expected the session to survive the rename, got %+v", sessions)
+	}
+	if sessions[0].TmuxSession != "project" {
+		t.Errorf("expected TmuxSession to be updated to %q, got %q", "project", sessions[0].TmuxSession)
+	}
+	if sessions[0].TmuxTarget != "project:0.0" {
+		t.Errorf("expected TmuxTarget to be rebuilt from the new session name, got %q", sessions[0].TmuxTarget)
+	}
+
+	cancel()
+	<-done
+}
+
+func TestWatch_UnrelatedNotificationIsIgnored(t *testing.T) {
+	client := newFakeControlClient([]string{"%1 @1 work:0.0"})
+	initial := []Session{{SessionID: "s1", TmuxTarget: "work:0.0", TmuxSession: "work"}}
+	updates := make(chan []Session, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- watch(ctx, client, initial, updates) }()
+
+	client.push(tmux.Notification{Name: "output", Args: []string{"%1", "hello"}})
+
+	select {
+	case sessions := <-updates:
+		t.Fatalf("expected no update for an unrelated notification, got %+v", sessions)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	cancel()
+	<-done
+}
+
+func TestWatch_ReturnsNilWhenNotificationsChannelCloses(t *testing.T) {
+	client := newFakeControlClient(nil)
+	updates := make(chan []Session, 1)
+
+	done := make(chan error, 1)
+	go func() { done <- watch(context.Background(), client, nil, updates) }()
+
+	close(client.notifications)
+
+	if err := <-done; err != nil {
+		t.Errorf("expected nil after the notifications channel closes, got %v", err)
+	}
+}
+
+func TestWatch_ReturnsContextErrOnCancel(t *testing.T) {
+	client := newFakeControlClient(nil)
+	updates := make(chan []Session, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- watch(ctx, client, nil, updates) }()
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
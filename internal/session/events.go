@@ -2,17 +2,21 @@ package session
 
 import (
 	"bufio"
+	"container/heap"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"syscall"
 	"time"
 )
 
-// RawEvent represents a single JSON line from the events log.
+// RawEvent represents a single JSON line from the events log. Level, Msg,
+// and Tokens are optional and only populated by writers using the
+// structured-logging fields; older lines simply omit them.
 type RawEvent struct {
 	Timestamp int64  `json:"ts"`
 	SessionID string `json:"sid"`
@@ -21,9 +25,14 @@ type RawEvent struct {
 	CWD       string `json:"cwd"`
 	TmuxInfo  string `json:"tmux"`
 	ToolName  string `json:"tool"`
+	Level     string `json:"level,omitempty"`
+	Msg       string `json:"msg,omitempty"`
+	Tokens    int    `json:"tokens,omitempty"`
 }
 
-// eventLogPath returns the path to the events log file.
+// eventLogPath returns the path to the legacy, pre-sharding single events
+// log file. It is still written to by old installs, so it is read as an
+// additional shard rather than abandoned.
 func eventLogPath() string {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
@@ -32,38 +41,186 @@ func eventLogPath() string {
 	return filepath.Join(homeDir, ".claude-tmux", "events.log")
 }
 
-// ReadSessions reads the event log and derives the current set of active sessions.
-// It returns an empty slice if the log file does not exist.
-func ReadSessions() ([]Session, error) {
-	logPath := eventLogPath()
-	if logPath == "" {
-		return nil, nil
+// eventsDir returns the directory holding per-writer event log shards.
+func eventsDir() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
 	}
+	return filepath.Join(homeDir, ".claude-tmux", "events.d")
+}
 
-	file, err := os.Open(logPath)
+// eventShardPath returns the path of the event log shard owned by the
+// current process. Each hook invocation gets its own shard file, named by
+// hostname and PID, so concurrent writers on NFS or a shared home directory
+// never contend for the same file and can never interleave partial lines.
+func eventShardPath() (string, error) {
+	dir := eventsDir()
+	if dir == "" {
+		return "", fmt.Errorf("failed to determine home directory")
+	}
+	hostname, err := os.Hostname()
 	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, nil
+		hostname = "unknown"
+	}
+	return filepath.Join(dir, fmt.Sprintf("%s-%d.log", hostname, os.Getpid())), nil
+}
+
+// shardPaths returns the paths of all event log shards to read, ordered by
+// modification time. The merge breaks timestamp ties by shard path, so its
+// output doesn't depend on this order. The legacy events.log is included as an additional shard, if present, so old
+// installs keep working through the migration.
+func shardPaths() ([]string, error) {
+	var paths []string
+
+	if dir := eventsDir(); dir != "" {
+		entries, err := os.ReadDir(dir)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to list event shards: %w", err)
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".log") {
+				continue
+			}
+			paths = append(paths, filepath.Join(dir, entry.Name()))
+		}
+	}
+
+	if legacy := eventLogPath(); legacy != "" {
+		if _, err := os.Stat(legacy); err == nil {
+			paths = append(paths, legacy)
 		}
-		return nil, fmt.Errorf("failed to open event log: %w", err)
 	}
-	defer file.Close()
 
-	sessionMap := make(map[string]*Session)
+	sort.Slice(paths, func(i, j int) bool {
+		infoI, errI := os.Stat(paths[i])
+		infoJ, errJ := os.Stat(paths[j])
+		if errI != nil || errJ != nil {
+			return paths[i] < paths[j]
+		}
+		return infoI.ModTime().Before(infoJ.ModTime())
+	})
+
+	return paths, nil
+}
+
+// shardReader streams decoded events from one shard file's JSONL lines, in
+// order, skipping blank or malformed lines without disturbing other shards.
+type shardReader struct {
+	path    string
+	file    *os.File
+	scanner *bufio.Scanner
+}
 
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
+// openShardReader opens path for streaming. The caller must call close().
+func openShardReader(path string) (*shardReader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return &shardReader{path: path, file: file, scanner: bufio.NewScanner(file)}, nil
+}
+
+// next returns the shard's next decoded event, skipping blank and malformed
+// lines (including a truncated trailing line from a writer caught
+// mid-append), or false once the shard is exhausted.
+func (r *shardReader) next() (RawEvent, bool) {
+	for r.scanner.Scan() {
+		line := r.scanner.Text()
 		if strings.TrimSpace(line) == "" {
 			continue
 		}
 
 		var event RawEvent
 		if err := json.Unmarshal([]byte(line), &event); err != nil {
-			// Skip malformed lines gracefully
 			continue
 		}
+		return event, true
+	}
+	return RawEvent{}, false
+}
+
+func (r *shardReader) close() {
+	r.file.Close()
+}
+
+// mergeHeapItem pairs a shard's next pending event with the reader it came
+// from, so the heap can pull the shard's following event once it is popped.
+type mergeHeapItem struct {
+	event  RawEvent
+	reader *shardReader
+}
+
+// mergeHeap is a min-heap of shards' next pending event, ordered by
+// timestamp and tie-broken by shard path for determinism.
+type mergeHeap []*mergeHeapItem
+
+func (h mergeHeap) Len() int { return len(h) }
+func (h mergeHeap) Less(i, j int) bool {
+	if h[i].event.Timestamp != h[j].event.Timestamp {
+		return h[i].event.Timestamp < h[j].event.Timestamp
+	}
+	return h[i].reader.path < h[j].reader.path
+}
+func (h mergeHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *mergeHeap) Push(x interface{}) {
+	*h = append(*h, x.(*mergeHeapItem))
+}
+func (h *mergeHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
+
+// mergeShardEvents k-way merges the JSONL events of each shard path into a
+// single stream ordered by timestamp, so ReadSessions can run its state
+// machine over a deterministic sequence regardless of which shard a writer
+// raced to append to. A shard that fails to open (e.g. removed between
+// shardPaths and here) is simply skipped.
+func mergeShardEvents(paths []string) []RawEvent {
+	var readers []*shardReader
+	defer func() {
+		for _, r := range readers {
+			r.close()
+		}
+	}()
+
+	h := &mergeHeap{}
+	heap.Init(h)
+
+	for _, path := range paths {
+		reader, err := openShardReader(path)
+		if err != nil {
+			continue
+		}
+		readers = append(readers, reader)
+
+		if event, ok := reader.next(); ok {
+			heap.Push(h, &mergeHeapItem{event: event, reader: reader})
+		}
+	}
+
+	var merged []RawEvent
+	for h.Len() > 0 {
+		item := heap.Pop(h).(*mergeHeapItem)
+		merged = append(merged, item.event)
 
+		if event, ok := item.reader.next(); ok {
+			heap.Push(h, &mergeHeapItem{event: event, reader: item.reader})
+		}
+	}
+
+	return merged
+}
+
+// applyEvents folds a timestamp-ordered stream of events into sessionMap,
+// mutating or creating entries in place. It is used both for a full replay
+// of every shard and for replaying just the events a snapshot hasn't seen
+// yet, so the two stay behaviorally identical.
+func applyEvents(sessionMap map[string]*Session, events []RawEvent) {
+	for _, event := range events {
 		if event.SessionID == "" {
 			continue
 		}
@@ -77,7 +234,7 @@ func ReadSessions() ([]Session, error) {
 				SessionID:   event.SessionID,
 				ClaudePID:   event.PID,
 				WorkDir:     event.CWD,
-				ProjectName: filepath.Base(event.CWD),
+				ProjectName: projectNameFor(event.CWD, event.TmuxInfo),
 				TmuxTarget:  event.TmuxInfo,
 				TmuxSession: tmuxSession,
 				WindowIndex: windowIndex,
@@ -98,7 +255,7 @@ func ReadSessions() ([]Session, error) {
 					SessionID:   event.SessionID,
 					ClaudePID:   event.PID,
 					WorkDir:     event.CWD,
-					ProjectName: filepath.Base(event.CWD),
+					ProjectName: projectNameFor(event.CWD, event.TmuxInfo),
 					TmuxTarget:  event.TmuxInfo,
 					TmuxSession: tmuxSession,
 					WindowIndex: windowIndex,
@@ -112,7 +269,7 @@ func ReadSessions() ([]Session, error) {
 			// Update CWD if provided (it may change during a session)
 			if event.CWD != "" {
 				session.WorkDir = event.CWD
-				session.ProjectName = filepath.Base(event.CWD)
+				session.ProjectName = projectNameFor(event.CWD, session.TmuxTarget)
 			}
 			// Note: TmuxTarget is intentionally NOT updated from regular events.
 			// It is only set from session-start or initial creation of unknown sessions.
@@ -125,9 +282,81 @@ func ReadSessions() ([]Session, error) {
 			applyEventStatus(session, event)
 		}
 	}
+}
 
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("failed to read event log: %w", err)
+// ReadSessions reads all event log shards and derives the current set of
+// active sessions from their timestamp-merged stream. It returns an empty
+// slice if no shards exist yet. A snapshot checkpoint lets repeat calls
+// replay only newly appended log bytes instead of reparsing everything.
+func ReadSessions() ([]Session, error) {
+	paths, err := shardPaths()
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	stats := make(map[string]os.FileInfo, len(paths))
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat event shard %q: %w", p, err)
+		}
+		stats[p] = info
+	}
+
+	logMap, err := sessionMapFromLog(paths, stats)
+	if err != nil {
+		return nil, err
+	}
+
+	return finalizeSessionMap(logMap)
+}
+
+// ReadSessionsWithHistory behaves like ReadSessions, but recovers sessions
+// whose session-start event has already scrolled out of a shard's live file
+// by additionally scanning each shard's n most recently rotated archives (0
+// means no archives at all, matching a shard with nothing rotated yet). It
+// always does a full log read rather than consulting the snapshot
+// checkpoint, since that checkpoint only ever tracks live-file offsets.
+func ReadSessionsWithHistory(n int) ([]Session, error) {
+	paths, err := shardPaths()
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	logMap := make(map[string]*Session)
+	if n <= 0 {
+		// shardEventsWithHistoryN reads 0 as "every archive"; read the live
+		// files alone instead.
+		applyEvents(logMap, mergeShardEvents(paths))
+		return finalizeSessionMap(logMap)
+	}
+
+	byPath := make(map[string][]RawEvent, len(paths))
+	for _, p := range paths {
+		byPath[p] = shardEventsWithHistoryN(p, n)
+	}
+
+	applyEvents(logMap, mergeEventsByPath(byPath))
+
+	return finalizeSessionMap(logMap)
+}
+
+// finalizeSessionMap prunes dead and orphaned sessions out of logMap,
+// deduplicates sessions sharing a tmux pane, and returns what's left as a
+// sorted slice. It never mutates logMap itself, so a caller holding onto it
+// (e.g. as cached snapshot state) keeps what the log actually says happened,
+// not the liveness checks re-run fresh on every call.
+func finalizeSessionMap(logMap map[string]*Session) ([]Session, error) {
+	sessionMap := make(map[string]*Session, len(logMap))
+	for id, s := range logMap {
+		cp := *s
+		sessionMap[id] = &cp
 	}
 
 	// Prune dead sessions: check if ClaudePID is still alive
@@ -137,6 +366,22 @@ func ReadSessions() ([]Session, error) {
 		}
 	}
 
+	// Prune orphans: a Claude process can linger after its tmux pane is
+	// killed, leaving a session whose PID is alive but whose TmuxTarget no
+	// longer exists. liveTargets is nil if liveness couldn't be determined
+	// (e.g. tmux isn't running), in which case pane-based pruning is skipped.
+	liveTargets, err := ActiveProber.LiveTargets()
+	if err != nil {
+		return nil, fmt.Errorf("failed to probe live tmux panes: %w", err)
+	}
+	if liveTargets != nil {
+		for sessionID, session := range sessionMap {
+			if session.TmuxTarget != "" && !liveTargets[session.TmuxTarget] {
+				delete(sessionMap, sessionID)
+			}
+		}
+	}
+
 	// Deduplicate sessions sharing the same tmux pane.
 	// If two sessions claim the same non-empty TmuxTarget, keep only the one
 	// with the most recent LastUpdate. This handles stale log data from before
@@ -162,9 +407,11 @@ func ReadSessions() ([]Session, error) {
 		}
 	}
 
-	// Convert map to sorted slice
+	// Convert map to sorted slice, detecting each session's repository and
+	// work unit along the way so SortSessions can group by them.
 	sessions := make([]Session, 0, len(sessionMap))
 	for _, session := range sessionMap {
+		session.Repo, session.WorkUnit = detectWorkUnitContext(session.WorkDir)
 		sessions = append(sessions, *session)
 	}
 	SortSessions(sessions)
@@ -230,49 +477,19 @@ func parseTmuxTarget(target string) (sessionName string, windowIndex int, paneIn
 	return sessionName, windowIndex, paneIndex
 }
 
+// projectNameFor returns the project name a session at tmuxTarget should be
+// attributed to: whatever Launch pre-registered for that target, if
+// anything, otherwise the base name of cwd, the same fallback this package
+// has always used for a pane Launch never heard about.
+func projectNameFor(cwd, tmuxTarget string) string {
+	if name, ok := expectedProjectName(tmuxTarget); ok {
+		return name
+	}
+	return filepath.Base(cwd)
+}
+
 // isProcessAlive checks if a process with the given PID is still running.
 func isProcessAlive(pid int) bool {
 	err := syscall.Kill(pid, 0)
 	return err == nil
 }
-
-// RotateLog truncates the event log to the last 500 lines if it exceeds 1000 lines.
-// Called on startup to prevent unbounded log growth.
-func RotateLog() error {
-	logPath := eventLogPath()
-	if logPath == "" {
-		return nil
-	}
-
-	file, err := os.Open(logPath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return nil
-		}
-		return fmt.Errorf("failed to open event log for rotation: %w", err)
-	}
-	defer file.Close()
-
-	var lines []string
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-	}
-	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("failed to read event log for rotation: %w", err)
-	}
-
-	if len(lines) <= 1000 {
-		return nil
-	}
-
-	// Keep last 500 lines
-	keepLines := lines[len(lines)-500:]
-	content := strings.Join(keepLines, "\n") + "\n"
-
-	if err := os.WriteFile(logPath, []byte(content), 0644); err != nil {
-		return fmt.Errorf("failed to write rotated event log: %w", err)
-	}
-
-	return nil
-}
@@ -33,8 +33,15 @@ func writeTestLog(t *testing.T, lines []string) (cleanup func()) {
 	originalHome := os.Getenv("HOME")
 	os.Setenv("HOME", tmpDir)
 
+	// Tests build sessions around synthetic tmux targets, not a real tmux
+	// server, so leave pane-based liveness undetermined by default; tests
+	// that care about pruning install their own fakeProber.
+	originalProber := ActiveProber
+	ActiveProber = fakeProber{}
+
 	return func() {
 		os.Setenv("HOME", originalHome)
+		ActiveProber = originalProber
 	}
 }
 
@@ -389,19 +396,20 @@ func TestReadSessions_EmptySessionIDSkipped(t *testing.T) {
 	}
 }
 
-func TestRotateLog(t *testing.T) {
+func TestRotateLog_TriggeredBySizeNotLineCount(t *testing.T) {
 	tmpDir := t.TempDir()
 	logDir := filepath.Join(tmpDir, ".claude-tmux")
 	if err := os.MkdirAll(logDir, 0755); err != nil {
 		t.Fatalf("failed to create temp log dir: %v", err)
 	}
 
-	// Create a log with 1100 lines
+	// Many short lines that would have tripped the old 1000-line threshold,
+	// but stay well under even a tiny byte threshold individually — rotation
+	// here is driven entirely by total size.
 	var lines []string
 	for i := 0; i < 1100; i++ {
-		lines = append(lines, fmt.Sprintf(`{"ts":%d,"sid":"s1","event":"pre-tool-use","pid":1,"cwd":"/proj","tmux":"w:0.0","tool":"line-%d"}`, 1707900000+i, i))
+		lines = append(lines, fmt.Sprintf(`{"ts":%d,"sid":"s1","event":"stop","pid":1,"cwd":"/proj","tmux":"w:0.0","tool":""}`, 1707900000+i))
 	}
-
 	logFile := filepath.Join(logDir, "events.log")
 	content := strings.Join(lines, "\n") + "\n"
 	if err := os.WriteFile(logFile, []byte(content), 0644); err != nil {
@@ -412,29 +420,102 @@ func TestRotateLog(t *testing.T) {
 	os.Setenv("HOME", tmpDir)
 	defer os.Setenv("HOME", originalHome)
 
-	if err := RotateLog(); err != nil {
-		t.Fatalf("RotateLog error: %v", err)
+	policy := RotationPolicy{MaxBytes: int64(len(content)) + 1, MaxArchives: 5, Compress: true}
+	if err := RotateLogWithPolicy(policy); err != nil {
+		t.Fatalf("RotateLogWithPolicy error: %v", err)
+	}
+	if _, err := os.Stat(logFile + ".1"); err == nil {
+		t.Fatalf("expected no rotation: file size %d is under the %d threshold", len(content), policy.MaxBytes)
 	}
 
-	// Read back and count lines
-	rotatedContent, err := os.ReadFile(logFile)
+	policy.MaxBytes = int64(len(content)) - 1
+	if err := RotateLogWithPolicy(policy); err != nil {
+		t.Fatalf("RotateLogWithPolicy error: %v", err)
+	}
+
+	info, err := os.Stat(logFile)
 	if err != nil {
-		t.Fatalf("failed to read rotated log: %v", err)
+		t.Fatalf("failed to stat fresh log: %v", err)
+	}
+	if info.Size() != 0 {
+		t.Errorf("expected a fresh empty log after rotation, got size %d", info.Size())
 	}
 
-	rotatedLines := strings.Split(strings.TrimSpace(string(rotatedContent)), "\n")
-	if len(rotatedLines) != 500 {
-		t.Errorf("expected 500 lines after rotation, got %d", len(rotatedLines))
+	archived, err := os.ReadFile(logFile + ".1")
+	if err != nil {
+		t.Fatalf("failed to read archive: %v", err)
+	}
+	if string(archived) != content {
+		t.Errorf("expected the archive to hold the original content verbatim")
+	}
+}
+
+func TestRotateLog_OlderArchivesAreGzipped(t *testing.T) {
+	tmpDir := t.TempDir()
+	logDir := filepath.Join(tmpDir, ".claude-tmux")
+	if err := os.MkdirAll(logDir, 0755); err != nil {
+		t.Fatalf("failed to create temp log dir: %v", err)
+	}
+	logFile := filepath.Join(logDir, "events.log")
+	originalHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpDir)
+	defer os.Setenv("HOME", originalHome)
+
+	policy := RotationPolicy{MaxBytes: 10, MaxArchives: 5, Compress: true}
+
+	// Rotate three times, so generation 1 shifts to 2 then to 3.
+	for i := 0; i < 3; i++ {
+		content := fmt.Sprintf(`{"ts":%d,"sid":"s1","event":"stop","pid":1,"cwd":"/proj","tmux":"w:0.0","tool":""}`, 1707900000+i) + "\n"
+		if err := os.WriteFile(logFile, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write log: %v", err)
+		}
+		if err := RotateLogWithPolicy(policy); err != nil {
+			t.Fatalf("RotateLogWithPolicy error: %v", err)
+		}
+	}
+
+	if _, err := os.Stat(logFile + ".1"); err != nil {
+		t.Errorf("expected the newest archive %q to exist uncompressed: %v", logFile+".1", err)
 	}
+	if _, err := os.Stat(logFile + ".2.gz"); err != nil {
+		t.Errorf("expected an older archive to be gzipped at %q: %v", logFile+".2.gz", err)
+	}
+	if _, err := os.Stat(logFile + ".2"); err == nil {
+		t.Errorf("expected the uncompressed generation 2 file to have been replaced by the .gz, found both")
+	}
+}
 
-	// Verify the last line is the last original line (line-1099)
-	if !strings.Contains(rotatedLines[499], "line-1099") {
-		t.Errorf("expected last line to contain 'line-1099', got %q", rotatedLines[499])
+func TestRotateLog_MaxArchivesPrunesOldest(t *testing.T) {
+	tmpDir := t.TempDir()
+	logDir := filepath.Join(tmpDir, ".claude-tmux")
+	if err := os.MkdirAll(logDir, 0755); err != nil {
+		t.Fatalf("failed to create temp log dir: %v", err)
 	}
+	logFile := filepath.Join(logDir, "events.log")
+	originalHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpDir)
+	defer os.Setenv("HOME", originalHome)
+
+	policy := RotationPolicy{MaxBytes: 10, MaxArchives: 2, Compress: true}
 
-	// Verify the first kept line is line-600 (1100 - 500)
-	if !strings.Contains(rotatedLines[0], "line-600") {
-		t.Errorf("expected first kept line to contain 'line-600', got %q", rotatedLines[0])
+	for i := 0; i < 3; i++ {
+		content := fmt.Sprintf(`{"ts":%d,"sid":"s1","event":"stop","pid":1,"cwd":"/proj","tmux":"w:0.0","tool":""}`, 1707900000+i) + "\n"
+		if err := os.WriteFile(logFile, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write log: %v", err)
+		}
+		if err := RotateLogWithPolicy(policy); err != nil {
+			t.Fatalf("RotateLogWithPolicy error: %v", err)
+		}
+	}
+
+	if _, err := os.Stat(logFile + ".1"); err != nil {
+		t.Errorf("expected generation 1 to survive: %v", err)
+	}
+	if _, err := os.Stat(logFile + ".2.gz"); err != nil {
+		t.Errorf("expected generation 2 to survive: %v", err)
+	}
+	if _, err := os.Stat(logFile + ".3.gz"); !os.IsNotExist(err) {
+		t.Errorf("expected generation 3 to have been pruned past MaxArchives=2")
 	}
 }
 
@@ -684,14 +765,8 @@ func TestRotateLog_ExactBoundary(t *testing.T) {
 		t.Fatalf("failed to create temp log dir: %v", err)
 	}
 
-	// Create a log with exactly 1001 lines (just over the 1000 threshold)
-	var lines []string
-	for i := 0; i < 1001; i++ {
-		lines = append(lines, fmt.Sprintf(`{"ts":%d,"sid":"s1","event":"pre-tool-use","pid":1,"cwd":"/proj","tmux":"w:0.0","tool":"line-%d"}`, 1707900000+i, i))
-	}
-
 	logFile := filepath.Join(logDir, "events.log")
-	content := strings.Join(lines, "\n") + "\n"
+	content := fmt.Sprintf(`{"ts":1707900000,"sid":"s1","event":"stop","pid":1,"cwd":"/proj","tmux":"w:0.0","tool":""}`) + "\n"
 	if err := os.WriteFile(logFile, []byte(content), 0644); err != nil {
 		t.Fatalf("failed to write log: %v", err)
 	}
@@ -700,27 +775,20 @@ func TestRotateLog_ExactBoundary(t *testing.T) {
 	os.Setenv("HOME", tmpDir)
 	defer os.Setenv("HOME", originalHome)
 
-	if err := RotateLog(); err != nil {
-		t.Fatalf("RotateLog error: %v", err)
-	}
-
-	rotatedContent, err := os.ReadFile(logFile)
-	if err != nil {
-		t.Fatalf("failed to read rotated log: %v", err)
+	// A file one byte under MaxBytes should not rotate; exactly at it should.
+	policy := RotationPolicy{MaxBytes: int64(len(content)) + 1, MaxArchives: 5, Compress: true}
+	if err := RotateLogWithPolicy(policy); err != nil {
+		t.Fatalf("RotateLogWithPolicy error: %v", err)
 	}
-
-	rotatedLines := strings.Split(strings.TrimSpace(string(rotatedContent)), "\n")
-	if len(rotatedLines) != 500 {
-		t.Errorf("expected 500 lines after rotation, got %d", len(rotatedLines))
+	if _, err := os.Stat(logFile + ".1"); !os.IsNotExist(err) {
+		t.Fatalf("expected no rotation one byte under the MaxBytes boundary")
 	}
 
-	// First kept line should be line 501 (index 501, since 1001-500=501)
-	if !strings.Contains(rotatedLines[0], "line-501") {
-		t.Errorf("expected first kept line to contain 'line-501', got %q", rotatedLines[0])
+	policy.MaxBytes = int64(len(content))
+	if err := RotateLogWithPolicy(policy); err != nil {
+		t.Fatalf("RotateLogWithPolicy error: %v", err)
 	}
-
-	// Last line should be the original last line (line-1000)
-	if !strings.Contains(rotatedLines[499], "line-1000") {
-		t.Errorf("expected last line to contain 'line-1000', got %q", rotatedLines[499])
+	if _, err := os.Stat(logFile + ".1"); err != nil {
+		t.Fatalf("expected rotation exactly at the MaxBytes boundary: %v", err)
 	}
 }
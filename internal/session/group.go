@@ -0,0 +1,56 @@
+package session
+
+import (
+	"os"
+	"strconv"
+)
+
+// archiveGeneration extracts the generation number from an archive's path,
+// or 0 if it doesn't look like one of rotateShard's archives.
+func archiveGeneration(path string) int {
+	sub := archiveSuffix.FindStringSubmatch(path)
+	if sub == nil {
+		return 0
+	}
+	n, err := strconv.Atoi(sub[1])
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// segmentForIndex resolves a rotation group's segment index to its actual
+// file path: 0 is the live head file, and any other index is looked up
+// among the existing archives since its compression may have changed since
+// the caller last saw it.
+func segmentForIndex(path string, index int) (string, bool) {
+	if index == 0 {
+		if _, err := os.Stat(path); err == nil {
+			return path, true
+		}
+		return "", false
+	}
+	for _, archive := range listArchives(path) {
+		if archiveGeneration(archive) == index {
+			return archive, true
+		}
+	}
+	return "", false
+}
+
+// orderedSegments returns path's existing rotation-group segment indices in
+// chronological order: oldest archived generation first, down to the
+// newest (generation 1), followed by 0 for the live head file if present.
+func orderedSegments(path string) []int {
+	archives := listArchives(path) // oldest (highest generation) first
+	segments := make([]int, 0, len(archives)+1)
+	for _, archive := range archives {
+		if n := archiveGeneration(archive); n > 0 {
+			segments = append(segments, n)
+		}
+	}
+	if _, err := os.Stat(path); err == nil {
+		segments = append(segments, 0)
+	}
+	return segments
+}
@@ -0,0 +1,66 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRotateLog_MaxAgePrunesOldArchives(t *testing.T) {
+	homeDir := withShardHome(t)
+	path := filepath.Join(homeDir, ".claude-tmux", "events.d", "host-1.log")
+
+	writeShard(t, homeDir, "host-1.log", "line one\n")
+	if err := RotateLogWithPolicy(RotationPolicy{MaxBytes: 1, MaxArchives: 5, Compress: false}); err != nil {
+		t.Fatalf("RotateLogWithPolicy error: %v", err)
+	}
+	archive := path + ".1"
+	old := time.Now().Add(-48 * time.Hour)
+	if err := os.Chtimes(archive, old, old); err != nil {
+		t.Fatalf("failed to backdate archive mtime: %v", err)
+	}
+
+	// The next rotation shifts this stale archive from generation 1 to 2
+	// before pruning runs.
+	writeShard(t, homeDir, "host-1.log", "line two\n")
+	policy := RotationPolicy{MaxBytes: 1, MaxArchives: 5, Compress: false, MaxAge: 24 * time.Hour}
+	if err := RotateLogWithPolicy(policy); err != nil {
+		t.Fatalf("RotateLogWithPolicy error: %v", err)
+	}
+
+	if _, err := os.Stat(path + ".2"); !os.IsNotExist(err) {
+		t.Errorf("expected the stale archive to be pruned by MaxAge")
+	}
+	if _, err := os.Stat(path + ".1"); err != nil {
+		t.Errorf("expected the freshly rotated archive to survive: %v", err)
+	}
+}
+
+func TestRotateLog_MaxTotalBytesPrunesOldestFirst(t *testing.T) {
+	homeDir := withShardHome(t)
+	path := filepath.Join(homeDir, ".claude-tmux", "events.d", "host-1.log")
+
+	policy := RotationPolicy{MaxBytes: 1, MaxArchives: 10, Compress: false, MaxTotalBytes: 12}
+	for i := 0; i < 3; i++ {
+		writeShard(t, homeDir, "host-1.log", "0123456789\n")
+		if err := RotateLogWithPolicy(policy); err != nil {
+			t.Fatalf("RotateLogWithPolicy error: %v", err)
+		}
+	}
+
+	var archivedTotal int64
+	for _, archive := range listArchives(path) {
+		info, err := os.Stat(archive)
+		if err != nil {
+			t.Fatalf("failed to stat archive %s: %v", archive, err)
+		}
+		archivedTotal += info.Size()
+	}
+	if archivedTotal > policy.MaxTotalBytes {
+		t.Errorf("expected archived total to stay within MaxTotalBytes, got %d", archivedTotal)
+	}
+	if _, err := os.Stat(path + ".1"); err != nil {
+		t.Errorf("expected the newest archive to survive pruning: %v", err)
+	}
+}
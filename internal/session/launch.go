@@ -0,0 +1,138 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+
+	"github.com/Jevs21/claude-tmux/internal/config"
+)
+
+// configPath returns the path a user's declarative project layouts are read
+// from, alongside the YAML session templates in ~/.claude-tmux/sessions and
+// the event log shards in ~/.claude-tmux/events.d.
+func configPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".claude-tmux", "config.toml"), nil
+}
+
+// LoadConfig reads and parses the TOML project file at configPath. A missing
+// file is returned as an empty ProjectsFile rather than an error, the same
+// way Templates treats a missing templates directory.
+func LoadConfig() (*config.ProjectsFile, error) {
+	path, err := configPath()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return &config.ProjectsFile{}, nil
+		}
+		return nil, fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+
+	return config.LoadProjects(path)
+}
+
+// expectedTargets maps a tmux target ("session:window.pane") Launch expects
+// to create to the project name it was launched from, so a hook event
+// arriving for one of them before its pane's first session-start is
+// attributed correctly instead of falling back to its CWD's base name (see
+// projectNameFor in events.go).
+var (
+	expectedTargetsMu sync.RWMutex
+	expectedTargets   = map[string]string{}
+)
+
+// RegisterExpectedTargets records every window/pane target cfg's layout will
+// occupy, keyed to cfg.Name. Launch calls it before creating anything, so
+// the registration is in place no matter how long tmux takes to spin up.
+func RegisterExpectedTargets(cfg config.Project) {
+	expectedTargetsMu.Lock()
+	defer expectedTargetsMu.Unlock()
+
+	for windowIndex, window := range cfg.Windows {
+		paneCount := len(window.Panes)
+		if paneCount == 0 {
+			paneCount = 1
+		}
+		for paneIndex := 0; paneIndex < paneCount; paneIndex++ {
+			target := fmt.Sprintf("%s:%d.%d", cfg.Name, windowIndex, paneIndex)
+			expectedTargets[target] = cfg.Name
+		}
+	}
+}
+
+// expectedProjectName returns the project name RegisterExpectedTargets
+// recorded for target, if any.
+func expectedProjectName(target string) (string, bool) {
+	expectedTargetsMu.RLock()
+	defer expectedTargetsMu.RUnlock()
+	name, ok := expectedTargets[target]
+	return name, ok
+}
+
+// Launch creates cfg's tmux session, windows, and panes if the session
+// doesn't already exist (idempotent the same way restoreSession is, via
+// tmuxSessionExists — calling Launch again on a project that's already
+// running is a no-op besides re-registering its targets), then hands off to
+// config.Up to spawn the layout and send-keys each pane's Claude command.
+// It uses commanderProjectTmux rather than config.DefaultProjectTmux so
+// every tmux call — like every other tmux call this package makes — goes
+// through ActiveCommander and can be faked in tests. See the package doc
+// for how this fits in as a future CLI's entry point.
+func Launch(cfg config.Project) error {
+	RegisterExpectedTargets(cfg)
+
+	if tmuxSessionExists(cfg.Name) {
+		return nil
+	}
+	return config.Up(cfg, commanderProjectTmux{})
+}
+
+// commanderProjectTmux implements config.ProjectTmux with the same tmux
+// command lines config.execProjectTmux issues, but through ActiveCommander
+// instead of shelling out directly — the same substitution restoreSession
+// makes for its own tmux calls.
+type commanderProjectTmux struct{}
+
+func (commanderProjectTmux) NewSession(name, root string) error {
+	args := []string{"new-session", "-d", "-s", name}
+	if root != "" {
+		args = append(args, "-c", root)
+	}
+	return ActiveCommander.ExecSilently(exec.Command("tmux", args...))
+}
+
+func (commanderProjectTmux) NewWindow(session, name, root string) error {
+	args := []string{"new-window", "-t", session, "-n", name}
+	if root != "" {
+		args = append(args, "-c", root)
+	}
+	return ActiveCommander.ExecSilently(exec.Command("tmux", args...))
+}
+
+func (commanderProjectTmux) SplitWindow(target, direction, root string) error {
+	args := []string{"split-window", "-t", target}
+	if direction != "" {
+		args = append(args, direction)
+	}
+	if root != "" {
+		args = append(args, "-c", root)
+	}
+	return ActiveCommander.ExecSilently(exec.Command("tmux", args...))
+}
+
+func (commanderProjectTmux) SelectLayout(target, layout string) error {
+	return ActiveCommander.ExecSilently(exec.Command("tmux", "select-layout", "-t", target, layout))
+}
+
+func (commanderProjectTmux) SendKeys(target, command string) error {
+	return ActiveCommander.ExecSilently(exec.Command("tmux", "send-keys", "-t", target, command, "Enter"))
+}
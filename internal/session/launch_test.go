@@ -0,0 +1,84 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/Jevs21/claude-tmux/internal/config"
+)
+
+func TestRegisterExpectedTargets_CoversEveryWindowAndPane(t *testing.T) {
+	cfg := config.Project{
+		Name: "backend",
+		Windows: []config.ProjectWindow{
+			{Name: "claude", Panes: []config.ProjectPane{{Cmd: "claude"}}},
+			{Name: "server", Panes: []config.ProjectPane{{Cmd: "npm run dev"}, {Cmd: "tail -f app.log"}}},
+		},
+	}
+
+	RegisterExpectedTargets(cfg)
+
+	for _, target := range []string{"backend:0.0", "backend:1.0", "backend:1.1"} {
+		name, ok := expectedProjectName(target)
+		if !ok || name != "backend" {
+			t.Errorf("expected %q to resolve to project %q, got %q (ok=%v)", target, "backend", name, ok)
+		}
+	}
+}
+
+func TestLaunch_CreatesSessionAndRegistersTargetsWhenAbsent(t *testing.T) {
+	fake := &fakeCommander{
+		output:  map[string]string{},
+		failFor: map[string]bool{"tmux has-session -t frontend": true},
+	}
+	old := ActiveCommander
+	ActiveCommander = fake
+	defer func() { ActiveCommander = old }()
+
+	cfg := config.Project{
+		Name:    "frontend",
+		Windows: []config.ProjectWindow{{Name: "claude", Panes: []config.ProjectPane{{Cmd: "claude"}}}},
+	}
+
+	if err := Launch(cfg); err != nil {
+		t.Fatalf("Launch returned error: %v", err)
+	}
+
+	if name, ok := expectedProjectName("frontend:0.0"); !ok || name != "frontend" {
+		t.Errorf("expected frontend:0.0 to be registered, got %q (ok=%v)", name, ok)
+	}
+
+	wantCalls := []string{
+		"tmux has-session -t frontend",
+		"tmux new-session -d -s frontend",
+		"tmux send-keys -t frontend:claude claude Enter",
+	}
+	if len(fake.calls) != len(wantCalls) {
+		t.Fatalf("expected calls %v, got %v", wantCalls, fake.calls)
+	}
+	for i, want := range wantCalls {
+		if fake.calls[i] != want {
+			t.Errorf("call %d: expected %q, got %q", i, want, fake.calls[i])
+		}
+	}
+}
+
+func TestLaunch_SkipsCreationWhenSessionAlreadyExists(t *testing.T) {
+	fake := &fakeCommander{output: map[string]string{}}
+	old := ActiveCommander
+	ActiveCommander = fake
+	defer func() { ActiveCommander = old }()
+
+	cfg := config.Project{
+		Name:    "existing",
+		Windows: []config.ProjectWindow{{Name: "claude", Panes: []config.ProjectPane{{Cmd: "claude"}}}},
+	}
+
+	if err := Launch(cfg); err != nil {
+		t.Fatalf("Launch returned error: %v", err)
+	}
+
+	wantCalls := []string{"tmux has-session -t existing"}
+	if len(fake.calls) != len(wantCalls) {
+		t.Fatalf("expected calls %v, got %v", wantCalls, fake.calls)
+	}
+}
@@ -0,0 +1,108 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// snapshotFileVersion is bumped if the on-disk shape of a portable snapshot
+// ever needs to change incompatibly.
+const snapshotFileVersion = 1
+
+// portableSnapshot is the on-disk shape SaveSnapshot/LoadSnapshot read and
+// write: a version tag plus the same RestoreEntry values
+// SaveServerSnapshot uses for the fixed state-dir snapshot.
+type portableSnapshot struct {
+	Version int            `json:"version"`
+	Entries []RestoreEntry `json:"entries"`
+}
+
+// SaveSnapshot writes sessions' restorable fields to path as a versioned
+// JSON file. Unlike SaveServerSnapshot (which always writes to the fixed
+// state-dir path a server restart reads back from), path is caller-chosen —
+// a user can export their workspace layout anywhere (a dotfiles repo, a USB
+// drive) and restore from it later with LoadSnapshot.
+func SaveSnapshot(path string, sessions []Session) error {
+	entries := make([]RestoreEntry, 0, len(sessions))
+	for _, s := range sessions {
+		if s.TmuxSession == "" {
+			continue // detached sessions have nothing to restore a pane into
+		}
+		entries = append(entries, RestoreEntry{
+			WorkDir:     s.WorkDir,
+			ProjectName: s.ProjectName,
+			TmuxSession: s.TmuxSession,
+			WindowIndex: s.WindowIndex,
+			PaneIndex:   s.PaneIndex,
+			Status:      s.Status,
+		})
+	}
+
+	data, err := json.MarshalIndent(portableSnapshot{Version: snapshotFileVersion, Entries: entries}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// LoadSnapshot reads back a file SaveSnapshot wrote and reconstructs the
+// Session values it described, including a TmuxTarget rebuilt from
+// TmuxSession/WindowIndex/PaneIndex — enough to display, or to pass through
+// SnapshotEntries into Restore. Neither ClaudePID nor LastUpdate survive a
+// round trip, since the process a snapshot describes may not exist by the
+// time it's restored.
+func LoadSnapshot(path string) ([]Session, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var snap portableSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	sessions := make([]Session, 0, len(snap.Entries))
+	for _, e := range snap.Entries {
+		sessions = append(sessions, Session{
+			WorkDir:     e.WorkDir,
+			ProjectName: e.ProjectName,
+			TmuxSession: e.TmuxSession,
+			TmuxTarget:  fmt.Sprintf("%s:%d.%d", e.TmuxSession, e.WindowIndex, e.PaneIndex),
+			WindowIndex: e.WindowIndex,
+			PaneIndex:   e.PaneIndex,
+			Status:      e.Status,
+		})
+	}
+	SortSessions(sessions)
+	return sessions, nil
+}
+
+// SnapshotEntries converts sessions (typically LoadSnapshot's result) back
+// into the RestoreEntry values Restore accepts. See the package doc for how
+// this fits in as a future CLI's entry point.
+func SnapshotEntries(sessions []Session) []RestoreEntry {
+	entries := make([]RestoreEntry, 0, len(sessions))
+	for _, s := range sessions {
+		if s.TmuxSession == "" {
+			continue
+		}
+		entries = append(entries, RestoreEntry{
+			WorkDir:     s.WorkDir,
+			ProjectName: s.ProjectName,
+			TmuxSession: s.TmuxSession,
+			WindowIndex: s.WindowIndex,
+			PaneIndex:   s.PaneIndex,
+			Status:      s.Status,
+		})
+	}
+	return entries
+}
@@ -0,0 +1,52 @@
+package session
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveAndLoadSnapshot_RoundTrips(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "workspace.json")
+
+	sessions := []Session{
+		{WorkDir: "/tmp/a", ProjectName: "a", TmuxSession: "work", WindowIndex: 0, PaneIndex: 0, Status: StatusIdle},
+		{WorkDir: "/tmp/b", ProjectName: "b", TmuxSession: "work", WindowIndex: 1, PaneIndex: 0, Status: StatusBusy},
+		{WorkDir: "/tmp/c", ProjectName: "c", Status: StatusIdle}, // detached, should be skipped
+	}
+
+	if err := SaveSnapshot(path, sessions); err != nil {
+		t.Fatalf("SaveSnapshot: %v", err)
+	}
+
+	loaded, err := LoadSnapshot(path)
+	if err != nil {
+		t.Fatalf("LoadSnapshot: %v", err)
+	}
+	if len(loaded) != 2 {
+		t.Fatalf("expected 2 restorable sessions, got %d: %+v", len(loaded), loaded)
+	}
+	if loaded[0].TmuxTarget != "work:0.0" {
+		t.Errorf("expected TmuxTarget to be rebuilt, got %q", loaded[0].TmuxTarget)
+	}
+}
+
+func TestLoadSnapshot_MissingFileReturnsError(t *testing.T) {
+	if _, err := LoadSnapshot(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("expected an error for a missing snapshot file")
+	}
+}
+
+func TestSnapshotEntries_SkipsDetachedSessions(t *testing.T) {
+	sessions := []Session{
+		{WorkDir: "/tmp/a", TmuxSession: "work", WindowIndex: 0, PaneIndex: 0},
+		{WorkDir: "/tmp/b"}, // detached
+	}
+	entries := SnapshotEntries(sessions)
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d: %+v", len(entries), entries)
+	}
+	if entries[0].TmuxSession != "work" {
+		t.Errorf("unexpected entry: %+v", entries[0])
+	}
+}
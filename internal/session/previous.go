@@ -0,0 +1,95 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// StateDir is the directory claude-tmux stores small persisted state files
+// in, such as the last-attached session. It defaults to
+// ${XDG_STATE_HOME}/claude-tmux (falling back to ~/.local/state/claude-tmux)
+// and is a variable so tests can redirect it to a temp directory.
+var StateDir = defaultStateDir()
+
+// defaultStateDir computes StateDir's initial value.
+func defaultStateDir() string {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, "claude-tmux")
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".local", "state", "claude-tmux")
+}
+
+// lastSessionPath returns the path of the file recording the most recently
+// attached session's tmux target.
+func lastSessionPath() string {
+	return filepath.Join(StateDir, "last")
+}
+
+// MarkAttached records s as the most-recently-attached session, so a later
+// Previous() call can resolve back to it. It is a no-op for detached
+// sessions, since there is no tmux target to jump back to.
+func (s Session) MarkAttached() error {
+	if s.TmuxTarget == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(StateDir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(lastSessionPath(), []byte(s.TmuxTarget), 0644)
+}
+
+// Previous returns the most recently attached session, matched against the
+// current set of live sessions by tmux target. It returns nil, nil if no
+// previous session was recorded, or if the recorded session is no longer
+// live.
+func Previous() (*Session, error) {
+	target, err := readLastTarget()
+	if err != nil || target == "" {
+		return nil, err
+	}
+
+	sessions, err := ReadSessions()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range sessions {
+		if sessions[i].TmuxTarget == target {
+			return &sessions[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// readLastTarget reads the recorded previous tmux target, returning "" if
+// none has been recorded yet.
+func readLastTarget() (string, error) {
+	data, err := os.ReadFile(lastSessionPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// MarkPrevious sets IsPrevious on the session (if any) whose tmux target
+// matches the previously attached session, so callers can render it with a
+// distinct marker.
+func MarkPrevious(sessions []Session) {
+	target, err := readLastTarget()
+	if err != nil || target == "" {
+		return
+	}
+
+	for i := range sessions {
+		sessions[i].IsPrevious = sessions[i].TmuxTarget == target
+	}
+}
@@ -0,0 +1,123 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// withTempStateDir redirects StateDir to a temp directory for the duration
+// of the test, restoring the previous value afterward.
+func withTempStateDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	old := StateDir
+	StateDir = dir
+	t.Cleanup(func() { StateDir = old })
+	return dir
+}
+
+func TestMarkAttached_WritesLastTarget(t *testing.T) {
+	dir := withTempStateDir(t)
+
+	s := Session{TmuxTarget: "work:0.0"}
+	if err := s.MarkAttached(); err != nil {
+		t.Fatalf("MarkAttached returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "last"))
+	if err != nil {
+		t.Fatalf("failed to read last-session file: %v", err)
+	}
+	if string(data) != "work:0.0" {
+		t.Errorf("expected %q, got %q", "work:0.0", string(data))
+	}
+}
+
+func TestMarkAttached_SkipsDetachedSessions(t *testing.T) {
+	dir := withTempStateDir(t)
+
+	s := Session{TmuxTarget: ""}
+	if err := s.MarkAttached(); err != nil {
+		t.Fatalf("MarkAttached returned error: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "last")); !os.IsNotExist(err) {
+		t.Errorf("expected no last-session file to be written for a detached session")
+	}
+}
+
+func TestMarkPrevious_SetsFlagOnMatchingSession(t *testing.T) {
+	withTempStateDir(t)
+
+	s := Session{TmuxTarget: "work:0.0"}
+	if err := s.MarkAttached(); err != nil {
+		t.Fatalf("MarkAttached returned error: %v", err)
+	}
+
+	sessions := []Session{
+		{SessionID: "a", TmuxSession: "work", WindowIndex: 0, TmuxTarget: "work:0.0"},
+		{SessionID: "b", TmuxSession: "dev", WindowIndex: 1, TmuxTarget: "dev:1.0"},
+	}
+	MarkPrevious(sessions)
+
+	if !sessions[0].IsPrevious {
+		t.Error("expected matching session to be marked previous")
+	}
+	if sessions[1].IsPrevious {
+		t.Error("expected non-matching session to not be marked previous")
+	}
+	if sessions[0].DisplayTarget() != "work:0 -" {
+		t.Errorf("expected DisplayTarget to include the previous marker, got %q", sessions[0].DisplayTarget())
+	}
+}
+
+func TestPrevious_ResolvesAgainstLiveSessions(t *testing.T) {
+	withTempStateDir(t)
+	currentPID := os.Getpid()
+	cleanup := writeTestLog(t, []string{
+		fmt.Sprintf(`{"ts":1707900000,"sid":"abc","event":"session-start","pid":%d,"cwd":"/tmp/proj","tmux":"work:0.0","tool":""}`, currentPID),
+	})
+	defer cleanup()
+
+	if err := (Session{TmuxTarget: "work:0.0"}).MarkAttached(); err != nil {
+		t.Fatalf("MarkAttached returned error: %v", err)
+	}
+
+	prev, err := Previous()
+	if err != nil {
+		t.Fatalf("Previous returned error: %v", err)
+	}
+	if prev == nil {
+		t.Fatal("expected a previous session to be found")
+	}
+	if prev.SessionID != "abc" {
+		t.Errorf("expected session ID 'abc', got %q", prev.SessionID)
+	}
+}
+
+func TestPrevious_NilWhenNothingRecorded(t *testing.T) {
+	withTempStateDir(t)
+	cleanup := writeTestLog(t, nil)
+	defer cleanup()
+
+	prev, err := Previous()
+	if err != nil {
+		t.Fatalf("Previous returned error: %v", err)
+	}
+	if prev != nil {
+		t.Errorf("expected no previous session, got %+v", prev)
+	}
+}
+
+func TestMarkPrevious_NoopWhenNothingRecorded(t *testing.T) {
+	withTempStateDir(t)
+
+	sessions := []Session{{SessionID: "a", TmuxTarget: "work:0.0"}}
+	MarkPrevious(sessions)
+
+	if sessions[0].IsPrevious {
+		t.Error("expected no session to be marked previous when nothing was recorded")
+	}
+}
@@ -0,0 +1,47 @@
+package session
+
+import (
+	"bufio"
+	"os/exec"
+	"strings"
+)
+
+// TmuxProber reports which tmux targets ("session:window.pane") are
+// currently live, so ReadSessions can drop sessions whose Claude process
+// has outlived the tmux pane it was running in.
+type TmuxProber interface {
+	// LiveTargets returns the set of currently live tmux targets. A nil map
+	// with a nil error means liveness could not be determined (e.g. tmux
+	// isn't running), in which case callers should skip pane-based pruning
+	// rather than treat every session as dead.
+	LiveTargets() (map[string]bool, error)
+}
+
+// ActiveProber is the TmuxProber ReadSessions consults. Tests substitute a
+// fake to supply a fixed set of live targets without needing a real tmux
+// binary or server.
+var ActiveProber TmuxProber = defaultProber{}
+
+// defaultProber shells out to tmux to list every live pane across all
+// sessions.
+type defaultProber struct{}
+
+func (defaultProber) LiveTargets() (map[string]bool, error) {
+	cmd := exec.Command("tmux", "list-panes", "-a",
+		"-F", "#{session_name}:#{window_index}.#{pane_index}")
+	output, err := ActiveCommander.Exec(cmd)
+	if err != nil {
+		// tmux isn't running, or has no panes — liveness can't be
+		// determined; let the caller fall back to PID-based pruning alone.
+		return nil, nil
+	}
+
+	targets := make(map[string]bool)
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		if target := strings.TrimSpace(scanner.Text()); target != "" {
+			targets[target] = true
+		}
+	}
+	return targets, nil
+}
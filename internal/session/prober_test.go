@@ -0,0 +1,92 @@
+package session
+
+import (
+	"os/exec"
+	"testing"
+)
+
+// fakeProber is a TmuxProber test double. A nil Targets map means liveness
+// is undetermined (matching defaultProber's fail-open behavior when tmux
+// isn't reachable), so callers that don't care about pane-based pruning can
+// use the zero value to make every session survive as before this feature.
+type fakeProber struct {
+	Targets map[string]bool
+}
+
+func (f fakeProber) LiveTargets() (map[string]bool, error) {
+	return f.Targets, nil
+}
+
+// withFakeProber installs p as ActiveProber for the duration of the test.
+func withFakeProber(t *testing.T, p TmuxProber) {
+	t.Helper()
+	old := ActiveProber
+	ActiveProber = p
+	t.Cleanup(func() { ActiveProber = old })
+}
+
+type erroringProber struct{}
+
+func (erroringProber) LiveTargets() (map[string]bool, error) {
+	return nil, exec.ErrNotFound
+}
+
+func TestReadSessions_PrunesSessionWithDeadPane(t *testing.T) {
+	cleanup := writeTestLog(t, []string{
+		`{"ts":1707900000,"sid":"s1","event":"session-start","pid":1,"cwd":"/proj","tmux":"work:0.0","tool":""}`,
+	})
+	withFakeProber(t, fakeProber{Targets: map[string]bool{}})
+	defer cleanup()
+
+	sessions, err := ReadSessions()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sessions) != 0 {
+		t.Fatalf("expected the session whose pane is gone to be pruned, got %d sessions", len(sessions))
+	}
+}
+
+func TestReadSessions_KeepsSessionWithLivePane(t *testing.T) {
+	cleanup := writeTestLog(t, []string{
+		`{"ts":1707900000,"sid":"s1","event":"session-start","pid":1,"cwd":"/proj","tmux":"work:0.0","tool":""}`,
+	})
+	withFakeProber(t, fakeProber{Targets: map[string]bool{"work:0.0": true}})
+	defer cleanup()
+
+	sessions, err := ReadSessions()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sessions) != 1 {
+		t.Fatalf("expected the session whose pane is live to survive, got %d sessions", len(sessions))
+	}
+}
+
+func TestReadSessions_UndeterminedLivenessSkipsPanePruning(t *testing.T) {
+	cleanup := writeTestLog(t, []string{
+		`{"ts":1707900000,"sid":"s1","event":"session-start","pid":1,"cwd":"/proj","tmux":"work:0.0","tool":""}`,
+	})
+	withFakeProber(t, fakeProber{Targets: nil})
+	defer cleanup()
+
+	sessions, err := ReadSessions()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sessions) != 1 {
+		t.Fatalf("expected pane-based pruning to be skipped when liveness is undetermined, got %d sessions", len(sessions))
+	}
+}
+
+func TestReadSessions_ProberErrorPropagates(t *testing.T) {
+	cleanup := writeTestLog(t, []string{
+		`{"ts":1707900000,"sid":"s1","event":"session-start","pid":1,"cwd":"/proj","tmux":"work:0.0","tool":""}`,
+	})
+	withFakeProber(t, erroringProber{})
+	defer cleanup()
+
+	if _, err := ReadSessions(); err == nil {
+		t.Fatal("expected an error when the prober itself fails")
+	}
+}
@@ -0,0 +1,64 @@
+//go:build !linux && !(darwin && cgo)
+
+package procfs
+
+import (
+	"bufio"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// List shells out to ps and lsof. It's the fallback for platforms without a
+// native implementation above (or a cgo-less Darwin build), and costs one ps
+// subprocess plus one lsof subprocess per process rather than a single pass.
+func List() ([]Process, error) {
+	psOutput, err := exec.Command("ps", "-axo", "pid,ppid,comm").Output()
+	if err != nil {
+		return nil, fmt.Errorf("ps: %w", err)
+	}
+
+	var processes []Process
+	scanner := bufio.NewScanner(strings.NewReader(string(psOutput)))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 {
+			continue
+		}
+		pid, err := strconv.Atoi(fields[0])
+		if err != nil {
+			continue
+		}
+		ppid, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue
+		}
+		processes = append(processes, Process{
+			PID:  pid,
+			PPID: ppid,
+			Comm: fields[2],
+			Cwd:  cwdViaLsof(pid),
+		})
+	}
+	return processes, nil
+}
+
+// cwdViaLsof shells out to lsof for a single process's working directory,
+// returning "" if lsof isn't installed or the process has no reportable cwd.
+func cwdViaLsof(pid int) string {
+	output, err := exec.Command("lsof", "-p", strconv.Itoa(pid), "-Fn", "-a", "-d", "cwd").Output()
+	if err != nil {
+		return ""
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(string(output)))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "n") && len(line) > 1 {
+			return filepath.Clean(line[1:])
+		}
+	}
+	return ""
+}
@@ -0,0 +1,94 @@
+//go:build linux
+
+package procfs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// List reads /proc directly: each numeric entry gives PPID (via its stat
+// file) and comm (via its comm file) with no subprocess involved, and the
+// cwd symlink gives the working directory. A full refresh this way costs
+// zero child processes, unlike shelling out to ps and lsof.
+func List() ([]Process, error) {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, fmt.Errorf("read /proc: %w", err)
+	}
+
+	processes := make([]Process, 0, len(entries))
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		if proc, ok := readProcess(pid); ok {
+			processes = append(processes, proc)
+		}
+	}
+	return processes, nil
+}
+
+// readProcess gathers one process's fields, returning ok=false if it exited
+// (or became unreadable) partway through the read.
+func readProcess(pid int) (Process, bool) {
+	ppid, ok := readPPID(pid)
+	if !ok {
+		return Process{}, false
+	}
+	comm, ok := readComm(pid)
+	if !ok {
+		return Process{}, false
+	}
+	return Process{PID: pid, PPID: ppid, Comm: comm, Cwd: readCwd(pid)}, true
+}
+
+// readPPID parses /proc/<pid>/stat for the parent PID. The comm field is
+// skipped over by its closing paren rather than split on whitespace, since
+// command names can themselves contain spaces and parentheses.
+func readPPID(pid int) (int, bool) {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return 0, false
+	}
+
+	line := string(data)
+	closeParen := strings.LastIndexByte(line, ')')
+	if closeParen < 0 {
+		return 0, false
+	}
+
+	fields := strings.Fields(line[closeParen+1:])
+	if len(fields) < 2 {
+		return 0, false
+	}
+	ppid, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return 0, false
+	}
+	return ppid, true
+}
+
+// readComm reads /proc/<pid>/comm, which holds the kernel's (possibly
+// truncated) name for the process's executable.
+func readComm(pid int) (string, bool) {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/comm", pid))
+	if err != nil {
+		return "", false
+	}
+	return strings.TrimSuffix(string(data), "\n"), true
+}
+
+// readCwd resolves /proc/<pid>/cwd, returning "" if the process already
+// exited or we don't have permission to read it.
+func readCwd(pid int) string {
+	target, err := filepath.EvalSymlinks(fmt.Sprintf("/proc/%d/cwd", pid))
+	if err != nil {
+		return ""
+	}
+	return target
+}
@@ -0,0 +1,73 @@
+//go:build linux
+
+package procfs
+
+import (
+	"os"
+	"testing"
+)
+
+func TestReadPPID_Self(t *testing.T) {
+	ppid, ok := readPPID(os.Getpid())
+	if !ok {
+		t.Fatal("expected to read our own /proc/self/stat")
+	}
+	if ppid != os.Getppid() {
+		t.Errorf("expected ppid %d, got %d", os.Getppid(), ppid)
+	}
+}
+
+func TestReadPPID_NonexistentPID(t *testing.T) {
+	if _, ok := readPPID(maxPID()); ok {
+		t.Fatal("expected reading a nonexistent pid's stat to fail")
+	}
+}
+
+func TestReadComm_Self(t *testing.T) {
+	comm, ok := readComm(os.Getpid())
+	if !ok {
+		t.Fatal("expected to read our own /proc/self/comm")
+	}
+	if comm == "" {
+		t.Error("expected a non-empty comm")
+	}
+}
+
+func TestReadCwd_Self(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("os.Getwd: %v", err)
+	}
+	if got := readCwd(os.Getpid()); got != wd {
+		t.Errorf("expected cwd %q, got %q", wd, got)
+	}
+}
+
+func TestReadCwd_NonexistentPID(t *testing.T) {
+	if got := readCwd(maxPID()); got != "" {
+		t.Errorf("expected empty cwd for a nonexistent pid, got %q", got)
+	}
+}
+
+func TestList_IncludesSelf(t *testing.T) {
+	processes, err := List()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, p := range processes {
+		if p.PID == os.Getpid() {
+			if p.PPID != os.Getppid() {
+				t.Errorf("expected ppid %d, got %d", os.Getppid(), p.PPID)
+			}
+			return
+		}
+	}
+	t.Fatal("expected List to include our own process")
+}
+
+// maxPID returns a PID that's virtually guaranteed not to exist, to exercise
+// the "process is gone" paths.
+func maxPID() int {
+	return 1 << 22
+}
@@ -0,0 +1,13 @@
+// Package procfs discovers running processes without shelling out to ps or
+// lsof: natively via /proc on Linux, via libproc on Darwin, and by falling
+// back to ps/lsof everywhere else.
+package procfs
+
+// Process describes a single running process, gathered in one pass per
+// platform rather than one subprocess invocation per field.
+type Process struct {
+	PID  int
+	PPID int
+	Comm string // base executable/command name, e.g. "claude"
+	Cwd  string // resolved working directory, "" if it couldn't be read
+}
@@ -0,0 +1,154 @@
+package session
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// logLevelRank orders RawEvent.Level for LogQuery.LevelAtLeast comparisons.
+// A missing or unrecognized level is treated as "info".
+var logLevelRank = map[string]int{"debug": 0, "info": 1, "warn": 2, "error": 3}
+
+func levelRank(level string) int {
+	if rank, ok := logLevelRank[strings.ToLower(level)]; ok {
+		return rank
+	}
+	return logLevelRank["info"]
+}
+
+// LogQuery filters the events QueryLog returns from a shard's rotation
+// group. Zero-valued fields impose no filter.
+type LogQuery struct {
+	Since        time.Time
+	Until        time.Time
+	SessionID    string
+	LevelAtLeast string
+	Grep         *regexp.Regexp
+	Limit        int
+}
+
+// matches reports whether event satisfies every filter q sets.
+func (q LogQuery) matches(event RawEvent) bool {
+	t := time.Unix(event.Timestamp, 0)
+	if !q.Since.IsZero() && t.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && t.After(q.Until) {
+		return false
+	}
+	if q.SessionID != "" && event.SessionID != q.SessionID {
+		return false
+	}
+	if q.LevelAtLeast != "" && levelRank(event.Level) < levelRank(q.LevelAtLeast) {
+		return false
+	}
+	if q.Grep != nil {
+		haystack := event.Msg
+		if haystack == "" {
+			haystack = event.Event
+		}
+		if !q.Grep.MatchString(haystack) {
+			return false
+		}
+	}
+	return true
+}
+
+// readSegmentEvents decodes a single segment's (archive or live head)
+// JSONL events via SegmentReader.
+func readSegmentEvents(path string) ([]RawEvent, error) {
+	reader, err := OpenSegmentReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	var events []RawEvent
+	for {
+		event, ok := reader.Next()
+		if !ok {
+			break
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// QueryLog scans path's rotation group — the live head file and every
+// retained archive — newest to oldest, returning the events matching query
+// in that same newest-first order. It stops as soon as query.Limit matches
+// have been found, so a narrow query against a long history doesn't have to
+// decode segments it'll never need.
+func QueryLog(path string, query LogQuery) ([]RawEvent, error) {
+	segments := orderedSegments(path) // oldest to newest
+
+	var results []RawEvent
+	for i := len(segments) - 1; i >= 0; i-- {
+		segPath, ok := segmentForIndex(path, segments[i])
+		if !ok {
+			continue
+		}
+
+		events, err := readSegmentEvents(segPath)
+		if err != nil {
+			return nil, err
+		}
+
+		for j := len(events) - 1; j >= 0; j-- {
+			if !query.matches(events[j]) {
+				continue
+			}
+			results = append(results, events[j])
+			if query.Limit > 0 && len(results) >= query.Limit {
+				return results, nil
+			}
+		}
+	}
+	return results, nil
+}
+
+// SessionStats aggregates one session's token usage and tool invocation
+// counts, as derived by Stats from a shard's rotation group.
+type SessionStats struct {
+	SessionID   string
+	TotalTokens int
+	ToolCounts  map[string]int
+}
+
+// Stats aggregates token counts and tool invocation counts per session
+// across path's entire rotation group — every retained archive plus the
+// live head file — so usage survives rotation instead of resetting at each
+// boundary.
+func Stats(path string) (map[string]*SessionStats, error) {
+	stats := make(map[string]*SessionStats)
+
+	for _, idx := range orderedSegments(path) {
+		segPath, ok := segmentForIndex(path, idx)
+		if !ok {
+			continue
+		}
+
+		events, err := readSegmentEvents(segPath)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, event := range events {
+			if event.SessionID == "" {
+				continue
+			}
+			s, exists := stats[event.SessionID]
+			if !exists {
+				s = &SessionStats{SessionID: event.SessionID, ToolCounts: make(map[string]int)}
+				stats[event.SessionID] = s
+			}
+			s.TotalTokens += event.Tokens
+			if event.ToolName != "" {
+				s.ToolCounts[event.ToolName]++
+			}
+		}
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,125 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestQueryLog_ReturnsNewestFirstWithinLimit(t *testing.T) {
+	homeDir := withShardHome(t)
+	path := filepath.Join(homeDir, ".claude-tmux", "events.d", "host-1.log")
+
+	var content string
+	for i := 1; i <= 3; i++ {
+		content += fmt.Sprintf(`{"ts":%d,"sid":"s1","event":"pre-tool-use","pid":1,"cwd":"/p","tmux":"w:0.0","tool":"t%d"}`, i, i) + "\n"
+	}
+	writeShard(t, homeDir, "host-1.log", content)
+
+	results, err := QueryLog(path, LogQuery{Limit: 2})
+	if err != nil {
+		t.Fatalf("QueryLog error: %v", err)
+	}
+	if len(results) != 2 || results[0].ToolName != "t3" || results[1].ToolName != "t2" {
+		t.Fatalf("expected the two newest events in descending order, got %+v", results)
+	}
+}
+
+func TestQueryLog_FiltersBySessionSinceAndGrep(t *testing.T) {
+	homeDir := withShardHome(t)
+	path := filepath.Join(homeDir, ".claude-tmux", "events.d", "host-1.log")
+
+	content := `{"ts":100,"sid":"s1","event":"pre-tool-use","pid":1,"cwd":"/p","tmux":"w:0.0","tool":"Bash","msg":"running build"}` + "\n" +
+		`{"ts":200,"sid":"s2","event":"pre-tool-use","pid":1,"cwd":"/p","tmux":"w:0.0","tool":"Read","msg":"reading file"}` + "\n" +
+		`{"ts":300,"sid":"s1","event":"pre-tool-use","pid":1,"cwd":"/p","tmux":"w:0.0","tool":"Bash","msg":"running tests"}` + "\n"
+	writeShard(t, homeDir, "host-1.log", content)
+
+	results, err := QueryLog(path, LogQuery{
+		SessionID: "s1",
+		Since:     time.Unix(150, 0),
+		Grep:      regexp.MustCompile("running"),
+	})
+	if err != nil {
+		t.Fatalf("QueryLog error: %v", err)
+	}
+	if len(results) != 1 || results[0].Msg != "running tests" {
+		t.Fatalf("expected only the later s1 'running tests' event, got %+v", results)
+	}
+}
+
+func TestQueryLog_SpansArchivesAndHead(t *testing.T) {
+	homeDir := withShardHome(t)
+	path := filepath.Join(homeDir, ".claude-tmux", "events.d", "host-1.log")
+
+	writeShard(t, homeDir, "host-1.log",
+		`{"ts":1,"sid":"s1","event":"session-start","pid":1,"cwd":"/p","tmux":"w:0.0","tool":""}`+"\n")
+	if err := RotateLogWithPolicy(RotationPolicy{MaxBytes: 1, MaxArchives: 5, Compress: true}); err != nil {
+		t.Fatalf("RotateLogWithPolicy error: %v", err)
+	}
+	writeShard(t, homeDir, "host-1.log",
+		`{"ts":2,"sid":"s1","event":"stop","pid":1,"cwd":"/p","tmux":"w:0.0","tool":""}`+"\n")
+
+	results, err := QueryLog(path, LogQuery{})
+	if err != nil {
+		t.Fatalf("QueryLog error: %v", err)
+	}
+	if len(results) != 2 || results[0].Event != "stop" || results[1].Event != "session-start" {
+		t.Fatalf("expected both the archived and live events newest first, got %+v", results)
+	}
+}
+
+func TestStats_AggregatesTokensAndToolCountsAcrossRotation(t *testing.T) {
+	homeDir := withShardHome(t)
+	path := filepath.Join(homeDir, ".claude-tmux", "events.d", "host-1.log")
+
+	writeShard(t, homeDir, "host-1.log",
+		`{"ts":1,"sid":"s1","event":"pre-tool-use","pid":1,"cwd":"/p","tmux":"w:0.0","tool":"Bash","tokens":10}`+"\n")
+	if err := RotateLogWithPolicy(RotationPolicy{MaxBytes: 1, MaxArchives: 5, Compress: true}); err != nil {
+		t.Fatalf("RotateLogWithPolicy error: %v", err)
+	}
+	writeShard(t, homeDir, "host-1.log",
+		`{"ts":2,"sid":"s1","event":"pre-tool-use","pid":1,"cwd":"/p","tmux":"w:0.0","tool":"Bash","tokens":15}`+"\n"+
+			`{"ts":3,"sid":"s1","event":"pre-tool-use","pid":1,"cwd":"/p","tmux":"w:0.0","tool":"Read","tokens":5}`+"\n")
+
+	stats, err := Stats(path)
+	if err != nil {
+		t.Fatalf("Stats error: %v", err)
+	}
+	s1, ok := stats["s1"]
+	if !ok {
+		t.Fatalf("expected stats for session s1, got %+v", stats)
+	}
+	if s1.TotalTokens != 30 {
+		t.Errorf("expected 30 total tokens across the rotation boundary, got %d", s1.TotalTokens)
+	}
+	if s1.ToolCounts["Bash"] != 2 || s1.ToolCounts["Read"] != 1 {
+		t.Errorf("expected Bash:2 Read:1, got %+v", s1.ToolCounts)
+	}
+}
+
+func TestRotateLog_DefersWhenTrailingLineUnterminated(t *testing.T) {
+	homeDir := withShardHome(t)
+	path := filepath.Join(homeDir, ".claude-tmux", "events.d", "host-1.log")
+
+	// No trailing newline: a writer caught mid-append.
+	content := `{"ts":1,"sid":"s1","event":"pre-tool-use","pid":1,"cwd":"/p","tmux":"w:0.0","tool":"Bash"}`
+	writeShard(t, homeDir, "host-1.log", content)
+
+	if err := RotateLogWithPolicy(RotationPolicy{MaxBytes: 1, MaxArchives: 5}); err != nil {
+		t.Fatalf("RotateLogWithPolicy error: %v", err)
+	}
+
+	if _, err := os.Stat(path + ".1"); !os.IsNotExist(err) {
+		t.Fatalf("expected rotation to be deferred while the trailing line is unterminated")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read shard: %v", err)
+	}
+	if string(data) != content {
+		t.Fatalf("expected the shard to be left untouched, got %q", data)
+	}
+}
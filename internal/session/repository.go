@@ -0,0 +1,221 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// vcs abstracts the operations Repository needs from a version control
+// system, so each of git/hg/jj can be implemented independently.
+type vcs interface {
+	// name identifies the VCS for error messages and logging.
+	name() string
+	// currentWorkUnit returns the branch/bookmark/change checked out at rootDir.
+	currentWorkUnit(rootDir string) (string, error)
+	// workUnitExists reports whether workUnit still exists in rootDir.
+	workUnitExists(rootDir, workUnit string) (bool, error)
+}
+
+// Repository represents a version-controlled working directory.
+type Repository struct {
+	backend vcs
+	rootDir string
+}
+
+// DetectRepository walks up from path looking for a .git, .hg, or .jj
+// directory and returns a Repository rooted there. It returns an error if
+// no VCS directory is found before reaching the filesystem root.
+func DetectRepository(path string) (*Repository, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve absolute path: %w", err)
+	}
+
+	dir := absPath
+	for {
+		if backend := vcsAt(dir); backend != nil {
+			return &Repository{backend: backend, rootDir: dir}, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	return nil, fmt.Errorf("no .git, .hg, or .jj found above %s", absPath)
+}
+
+// detectWorkUnitContext detects the repository backing workDir and its
+// current work unit (branch/bookmark/change), for populating a Session's
+// Repo/WorkUnit fields. It returns (nil, "") if workDir is empty, isn't
+// inside a known VCS checkout, or the work unit can't be resolved — repo
+// detection is best-effort and never blocks session discovery.
+func detectWorkUnitContext(workDir string) (*Repository, string) {
+	if workDir == "" {
+		return nil, ""
+	}
+
+	repo, err := DetectRepository(workDir)
+	if err != nil {
+		return nil, ""
+	}
+
+	unit, err := repo.CurrentWorkUnit()
+	if err != nil {
+		return nil, ""
+	}
+
+	return repo, unit
+}
+
+// vcsAt returns the vcs backend whose marker directory is present directly
+// inside dir, or nil if none is found.
+func vcsAt(dir string) vcs {
+	switch {
+	case dirExists(filepath.Join(dir, ".git")):
+		return gitVCS{}
+	case dirExists(filepath.Join(dir, ".hg")):
+		return hgVCS{}
+	case dirExists(filepath.Join(dir, ".jj")):
+		return jjVCS{}
+	default:
+		return nil
+	}
+}
+
+func dirExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// Name returns the repository's directory basename.
+func (r *Repository) Name() string {
+	return filepath.Base(r.rootDir)
+}
+
+// RootDir returns the repository's root directory.
+func (r *Repository) RootDir() string {
+	return r.rootDir
+}
+
+// CurrentWorkUnit returns the current git branch, hg bookmark/branch, or jj
+// change identifier — whichever concept the repository's VCS uses to track
+// an independent line of work.
+func (r *Repository) CurrentWorkUnit() (string, error) {
+	unit, err := r.backend.currentWorkUnit(r.rootDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s work unit: %w", r.backend.name(), err)
+	}
+	return unit, nil
+}
+
+// WorkUnitExists reports whether workUnit still exists in the repository,
+// e.g. to detect branches that have since been deleted or merged.
+func (r *Repository) WorkUnitExists(workUnit string) (bool, error) {
+	return r.backend.workUnitExists(r.rootDir, workUnit)
+}
+
+// gitVCS implements vcs for git working directories.
+type gitVCS struct{}
+
+func (gitVCS) name() string { return "git" }
+
+func (gitVCS) currentWorkUnit(rootDir string) (string, error) {
+	out, err := ActiveCommander.Exec(exec.Command("git", "-C", rootDir, "symbolic-ref", "--short", "-q", "HEAD"))
+	if err == nil {
+		if branch := strings.TrimSpace(out); branch != "" {
+			return branch, nil
+		}
+	}
+
+	// Detached HEAD: fall back to the short commit hash.
+	out, err = ActiveCommander.Exec(exec.Command("git", "-C", rootDir, "rev-parse", "--short", "HEAD"))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+func (gitVCS) workUnitExists(rootDir, workUnit string) (bool, error) {
+	err := ActiveCommander.ExecSilently(exec.Command("git", "-C", rootDir, "show-ref", "--verify", "-q", "refs/heads/"+workUnit))
+	if err == nil {
+		return true, nil
+	}
+	if _, ok := err.(*exec.ExitError); ok {
+		return false, nil
+	}
+	return false, err
+}
+
+// hgVCS implements vcs for Mercurial working directories.
+type hgVCS struct{}
+
+func (hgVCS) name() string { return "hg" }
+
+func (hgVCS) currentWorkUnit(rootDir string) (string, error) {
+	out, err := ActiveCommander.Exec(exec.Command("hg", "--cwd", rootDir, "id", "-B"))
+	if err == nil {
+		if bookmark := strings.TrimSpace(out); bookmark != "" {
+			return bookmark, nil
+		}
+	}
+
+	out, err = ActiveCommander.Exec(exec.Command("hg", "--cwd", rootDir, "branch"))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+func (hgVCS) workUnitExists(rootDir, workUnit string) (bool, error) {
+	out, err := ActiveCommander.Exec(exec.Command("hg", "--cwd", rootDir, "bookmarks", "-q"))
+	if err != nil {
+		return false, err
+	}
+	for _, bookmark := range strings.Fields(out) {
+		if bookmark == workUnit {
+			return true, nil
+		}
+	}
+
+	out, err = ActiveCommander.Exec(exec.Command("hg", "--cwd", rootDir, "branches", "-q"))
+	if err != nil {
+		return false, err
+	}
+	for _, branch := range strings.Fields(out) {
+		if branch == workUnit {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// jjVCS implements vcs for Jujutsu working directories.
+type jjVCS struct{}
+
+func (jjVCS) name() string { return "jj" }
+
+func (jjVCS) currentWorkUnit(rootDir string) (string, error) {
+	out, err := ActiveCommander.Exec(exec.Command("jj", "-R", rootDir, "log", "-r", "@", "--no-graph", "-T", "bookmarks"))
+	if err == nil {
+		if bookmark := strings.TrimSpace(out); bookmark != "" {
+			return bookmark, nil
+		}
+	}
+
+	out, err = ActiveCommander.Exec(exec.Command("jj", "-R", rootDir, "log", "-r", "@", "--no-graph", "-T", "change_id.short()"))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+func (jjVCS) workUnitExists(rootDir, workUnit string) (bool, error) {
+	err := ActiveCommander.ExecSilently(exec.Command("jj", "-R", rootDir, "log", "-r", workUnit, "--no-graph", "-T", "change_id"))
+	return err == nil, nil
+}
@@ -0,0 +1,165 @@
+package session
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectRepository_Git(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, ".git"), 0755); err != nil {
+		t.Fatalf("failed to create .git dir: %v", err)
+	}
+	nested := filepath.Join(root, "a", "b")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatalf("failed to create nested dir: %v", err)
+	}
+
+	repo, err := DetectRepository(nested)
+	if err != nil {
+		t.Fatalf("DetectRepository returned error: %v", err)
+	}
+	if repo.RootDir() != root {
+		t.Errorf("expected root %q, got %q", root, repo.RootDir())
+	}
+	if repo.Name() != filepath.Base(root) {
+		t.Errorf("expected name %q, got %q", filepath.Base(root), repo.Name())
+	}
+	if _, ok := repo.backend.(gitVCS); !ok {
+		t.Errorf("expected gitVCS backend, got %T", repo.backend)
+	}
+}
+
+func TestDetectRepository_Mercurial(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, ".hg"), 0755); err != nil {
+		t.Fatalf("failed to create .hg dir: %v", err)
+	}
+
+	repo, err := DetectRepository(root)
+	if err != nil {
+		t.Fatalf("DetectRepository returned error: %v", err)
+	}
+	if _, ok := repo.backend.(hgVCS); !ok {
+		t.Errorf("expected hgVCS backend, got %T", repo.backend)
+	}
+}
+
+func TestDetectRepository_Jujutsu(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, ".jj"), 0755); err != nil {
+		t.Fatalf("failed to create .jj dir: %v", err)
+	}
+
+	repo, err := DetectRepository(root)
+	if err != nil {
+		t.Fatalf("DetectRepository returned error: %v", err)
+	}
+	if _, ok := repo.backend.(jjVCS); !ok {
+		t.Errorf("expected jjVCS backend, got %T", repo.backend)
+	}
+}
+
+func TestDetectRepository_NotFound(t *testing.T) {
+	root := t.TempDir()
+	if _, err := DetectRepository(root); err == nil {
+		t.Error("expected error for directory with no VCS marker")
+	}
+}
+
+func TestDetectRepository_NestedPrefersClosest(t *testing.T) {
+	outer := t.TempDir()
+	if err := os.Mkdir(filepath.Join(outer, ".git"), 0755); err != nil {
+		t.Fatalf("failed to create outer .git dir: %v", err)
+	}
+
+	inner := filepath.Join(outer, "vendor", "inner")
+	if err := os.MkdirAll(inner, 0755); err != nil {
+		t.Fatalf("failed to create inner dir: %v", err)
+	}
+	if err := os.Mkdir(filepath.Join(inner, ".git"), 0755); err != nil {
+		t.Fatalf("failed to create inner .git dir: %v", err)
+	}
+
+	repo, err := DetectRepository(inner)
+	if err != nil {
+		t.Fatalf("DetectRepository returned error: %v", err)
+	}
+	if repo.RootDir() != inner {
+		t.Errorf("expected closest root %q, got %q", inner, repo.RootDir())
+	}
+}
+
+// requireGit skips the test if the git binary isn't available.
+func requireGit(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+}
+
+func initGitRepo(t *testing.T, branch string) string {
+	t.Helper()
+	requireGit(t)
+
+	root := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = root
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+		)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+
+	run("init", "-q", "-b", branch)
+	run("commit", "--allow-empty", "-q", "-m", "initial")
+	return root
+}
+
+func TestGitWorkUnit_CurrentBranch(t *testing.T) {
+	root := initGitRepo(t, "feature-x")
+
+	repo, err := DetectRepository(root)
+	if err != nil {
+		t.Fatalf("DetectRepository returned error: %v", err)
+	}
+
+	workUnit, err := repo.CurrentWorkUnit()
+	if err != nil {
+		t.Fatalf("CurrentWorkUnit returned error: %v", err)
+	}
+	if workUnit != "feature-x" {
+		t.Errorf("expected work unit %q, got %q", "feature-x", workUnit)
+	}
+}
+
+func TestGitWorkUnitExists(t *testing.T) {
+	root := initGitRepo(t, "main")
+
+	repo, err := DetectRepository(root)
+	if err != nil {
+		t.Fatalf("DetectRepository returned error: %v", err)
+	}
+
+	exists, err := repo.WorkUnitExists("main")
+	if err != nil {
+		t.Fatalf("WorkUnitExists returned error: %v", err)
+	}
+	if !exists {
+		t.Error("expected 'main' to exist")
+	}
+
+	exists, err = repo.WorkUnitExists("does-not-exist")
+	if err != nil {
+		t.Fatalf("WorkUnitExists returned error: %v", err)
+	}
+	if exists {
+		t.Error("expected 'does-not-exist' to not exist")
+	}
+}
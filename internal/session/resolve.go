@@ -0,0 +1,127 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// repoRootOption is the tmux session-scoped user option used to remember
+// which repository root a session was created for, so later resolutions can
+// detect name collisions between repositories that share a basename.
+const repoRootOption = "@claude_tmux_repo_root"
+
+// ResolveFromCWD creates or attaches a tmux session named after the current
+// working directory's repository and work unit (e.g. "myrepo/main"), and
+// returns the resolved session name. If a different repository already owns
+// that name, the session is qualified as "parent-dir/repo/branch" instead.
+func ResolveFromCWD() (string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	repo, err := DetectRepository(cwd)
+	if err != nil {
+		return "", err
+	}
+
+	workUnit, err := repo.CurrentWorkUnit()
+	if err != nil {
+		return "", err
+	}
+
+	name := resolveSessionName(repo, workUnit)
+
+	if tmuxSessionExists(name) {
+		return name, attachTmuxSession(name)
+	}
+	return name, createTmuxSession(name, repo)
+}
+
+// resolveSessionName picks a tmux session name for repo+workUnit, qualifying
+// it with the parent directory when an existing tmux session already owns
+// the unqualified name for a different repository root.
+func resolveSessionName(repo *Repository, workUnit string) string {
+	name := repo.Name() + "/" + workUnit
+
+	existingRoot, ok := existingSessionRepoRoot(name)
+	if ok && existingRoot != repo.RootDir() {
+		parent := filepath.Base(filepath.Dir(repo.RootDir()))
+		return parent + "/" + name
+	}
+
+	return name
+}
+
+// existingSessionRepoRoot returns the repo root stored on an existing tmux
+// session named sessionName, and whether the session exists at all.
+func existingSessionRepoRoot(sessionName string) (root string, exists bool) {
+	if !tmuxSessionExists(sessionName) {
+		return "", false
+	}
+
+	out, err := ActiveCommander.Exec(exec.Command("tmux", "show-options", "-t", sessionName, "-qv", repoRootOption))
+	if err != nil {
+		return "", true
+	}
+	return strings.TrimSpace(out), true
+}
+
+// tmuxSessionExists reports whether a tmux session with the given name exists.
+func tmuxSessionExists(name string) bool {
+	err := ActiveCommander.ExecSilently(exec.Command("tmux", "has-session", "-t", name))
+	return err == nil
+}
+
+// createTmuxSession creates a detached tmux session rooted at repo's
+// directory, tags it with its repository root, and attaches to it.
+func createTmuxSession(name string, repo *Repository) error {
+	create := exec.Command("tmux", "new-session", "-d", "-s", name, "-c", repo.RootDir())
+	if err := ActiveCommander.ExecSilently(create); err != nil {
+		return fmt.Errorf("failed to create tmux session %q: %w", name, err)
+	}
+
+	tag := exec.Command("tmux", "set-option", "-t", name, repoRootOption, repo.RootDir())
+	if err := ActiveCommander.ExecSilently(tag); err != nil {
+		return fmt.Errorf("failed to tag tmux session %q: %w", name, err)
+	}
+
+	return attachTmuxSession(name)
+}
+
+// attachTmuxSession attaches to or switches to an existing tmux session,
+// mirroring the attach-vs-switch logic in tmux.Jump.
+func attachTmuxSession(name string) error {
+	if os.Getenv("TMUX") != "" {
+		return ActiveCommander.ExecSilently(exec.Command("tmux", "switch-client", "-t", name))
+	}
+	return ActiveCommander.ExecSilently(exec.Command("tmux", "attach-session", "-t", name))
+}
+
+// PruneStaleWorkUnitSessions kills tmux sessions among sessions whose
+// repository's work unit (branch/bookmark/change) no longer exists, e.g.
+// because it was merged and deleted. Sessions with no detected repository
+// are left untouched.
+func PruneStaleWorkUnitSessions(sessions []Session) error {
+	for _, s := range sessions {
+		if s.Repo == nil || s.WorkUnit == "" || s.TmuxSession == "" {
+			continue
+		}
+
+		exists, err := s.Repo.WorkUnitExists(s.WorkUnit)
+		if err != nil {
+			return fmt.Errorf("failed to check work unit %q for %s: %w", s.WorkUnit, s.Repo.Name(), err)
+		}
+		if exists {
+			continue
+		}
+
+		if err := ActiveCommander.ExecSilently(exec.Command("tmux", "kill-session", "-t", s.TmuxSession)); err != nil {
+			return fmt.Errorf("failed to kill stale session %q: %w", s.TmuxSession, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package session
+
+import "testing"
+
+func TestResolveSessionName_NoCollision(t *testing.T) {
+	// With no existing tmux server reachable in the test environment,
+	// tmuxSessionExists always reports false, so the name is never qualified.
+	repo := &Repository{backend: gitVCS{}, rootDir: "/tmp/myrepo"}
+	name := resolveSessionName(repo, "main")
+	if name != "myrepo/main" {
+		t.Errorf("expected %q, got %q", "myrepo/main", name)
+	}
+}
+
+func TestPruneStaleWorkUnitSessions_SkipsSessionsWithoutRepo(t *testing.T) {
+	sessions := []Session{
+		{TmuxSession: "detached-work", WorkUnit: "", Repo: nil},
+	}
+	if err := PruneStaleWorkUnitSessions(sessions); err != nil {
+		t.Errorf("expected no error for sessions without a repo, got %v", err)
+	}
+}
+
+func TestPruneStaleWorkUnitSessions_SkipsWhenBranchExists(t *testing.T) {
+	root := initGitRepo(t, "main")
+	repo, err := DetectRepository(root)
+	if err != nil {
+		t.Fatalf("DetectRepository returned error: %v", err)
+	}
+
+	sessions := []Session{
+		{TmuxSession: "myrepo/main", WorkUnit: "main", Repo: repo},
+	}
+	if err := PruneStaleWorkUnitSessions(sessions); err != nil {
+		t.Errorf("expected no error when branch still exists, got %v", err)
+	}
+}
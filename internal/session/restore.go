@@ -0,0 +1,224 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+)
+
+// RestoreEntry is one session captured into the server snapshot: enough to
+// recreate its tmux window/pane and relaunch Claude there after the tmux
+// server itself has restarted, at which point ClaudePID is meaningless and
+// TmuxTarget may no longer exist.
+type RestoreEntry struct {
+	WorkDir      string
+	ProjectName  string
+	TmuxSession  string
+	WindowIndex  int
+	PaneIndex    int
+	Status       Status
+	SpawnCommand string // command that launched Claude here, if known (e.g. via config.Up); defaults to "claude" on restore
+}
+
+// serverSnapshotPath returns the path the server snapshot is written to and
+// read from. It is distinct from the per-reader log-replay checkpoint in
+// snapshot.go: that one only skips re-parsed log bytes, while this one must
+// survive a tmux server restart, where the panes and PIDs it describes are
+// gone entirely.
+func serverSnapshotPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".local", "state", "claude-tmux", "snapshot.json"), nil
+}
+
+// SaveServerSnapshot serializes sessions' restorable fields to the server
+// snapshot file, overwriting whatever was there before. Callers re-run it
+// periodically (e.g. on every TUI refresh tick) so the snapshot never drifts
+// far from what's actually running.
+func SaveServerSnapshot(sessions []Session) error {
+	path, err := serverSnapshotPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+
+	entries := make([]RestoreEntry, 0, len(sessions))
+	for _, s := range sessions {
+		if s.TmuxSession == "" {
+			continue // detached sessions have nothing to restore a pane into
+		}
+		entries = append(entries, RestoreEntry{
+			WorkDir:     s.WorkDir,
+			ProjectName: s.ProjectName,
+			TmuxSession: s.TmuxSession,
+			WindowIndex: s.WindowIndex,
+			PaneIndex:   s.PaneIndex,
+			Status:      s.Status,
+		})
+	}
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal server snapshot: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// LoadServerSnapshot reads back the entries SaveServerSnapshot last wrote. A
+// missing file returns a nil slice rather than an error, the same way a
+// first run before anything has ever been snapshotted would.
+func LoadServerSnapshot() ([]RestoreEntry, error) {
+	path, err := serverSnapshotPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var entries []RestoreEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return entries, nil
+}
+
+// RestoreOptions controls Restore's behavior toward a tmux session that's
+// already present on the server under an entry's TmuxSession name.
+type RestoreOptions struct {
+	// Override kills and recreates a conflicting session instead of leaving
+	// it alone.
+	Override bool
+}
+
+// Restore recreates the tmux sessions/windows described by entries and
+// relaunches Claude in each one at its recorded WorkDir, skipping any pane
+// that's already there. It returns the target of the first entry it
+// (re)created, so a caller that wants a "restore --attach" can pass that to
+// session.Attach/SwitchClient; entries whose session already existed in full
+// contribute nothing to restore there. See the package doc for how this
+// fits in as a future CLI's entry point.
+func Restore(entries []RestoreEntry, opts RestoreOptions) (string, error) {
+	var firstTarget string
+
+	bySession := make(map[string][]RestoreEntry)
+	var order []string
+	for _, e := range entries {
+		if e.TmuxSession == "" {
+			continue
+		}
+		if _, ok := bySession[e.TmuxSession]; !ok {
+			order = append(order, e.TmuxSession)
+		}
+		bySession[e.TmuxSession] = append(bySession[e.TmuxSession], e)
+	}
+
+	for _, name := range order {
+		target, err := restoreSession(name, bySession[name], opts)
+		if err != nil {
+			return firstTarget, err
+		}
+		if firstTarget == "" {
+			firstTarget = target
+		}
+	}
+
+	return firstTarget, nil
+}
+
+// restoreSession recreates one tmux session (and its windows and panes)
+// from group, the RestoreEntry values recorded for it, and returns the
+// target of the first window it actually had to recreate.
+func restoreSession(name string, group []RestoreEntry, opts RestoreOptions) (string, error) {
+	exists := tmuxSessionExists(name)
+
+	if exists && opts.Override {
+		if err := ActiveCommander.ExecSilently(exec.Command("tmux", "kill-session", "-t", name)); err != nil {
+			return "", fmt.Errorf("failed to kill existing session %q for override: %w", name, err)
+		}
+		exists = false
+	}
+
+	byWindow := make(map[int][]RestoreEntry)
+	var windowOrder []int
+	for _, e := range group {
+		if _, ok := byWindow[e.WindowIndex]; !ok {
+			windowOrder = append(windowOrder, e.WindowIndex)
+		}
+		byWindow[e.WindowIndex] = append(byWindow[e.WindowIndex], e)
+	}
+	sort.Ints(windowOrder)
+
+	var firstTarget string
+	for _, windowIndex := range windowOrder {
+		panes := byWindow[windowIndex]
+		sort.Slice(panes, func(i, j int) bool { return panes[i].PaneIndex < panes[j].PaneIndex })
+		windowTarget := fmt.Sprintf("%s:%d", name, windowIndex)
+
+		if exists && windowExists(windowTarget) {
+			continue
+		}
+
+		for i, e := range panes {
+			// The window's first pane comes from new-session/new-window
+			// itself; every pane after it needs an explicit split to
+			// recreate a multi-pane layout.
+			paneTarget := windowTarget
+			switch {
+			case !exists:
+				if err := ActiveCommander.ExecSilently(exec.Command("tmux", "new-session", "-d", "-s", name, "-c", e.WorkDir)); err != nil {
+					return firstTarget, fmt.Errorf("failed to recreate session %q: %w", name, err)
+				}
+				exists = true
+			case i == 0:
+				if err := ActiveCommander.ExecSilently(exec.Command("tmux", "new-window", "-t", name, "-c", e.WorkDir)); err != nil {
+					return firstTarget, fmt.Errorf("failed to recreate window %q: %w", windowTarget, err)
+				}
+			default:
+				paneTarget = fmt.Sprintf("%s.%d", windowTarget, e.PaneIndex)
+				if err := ActiveCommander.ExecSilently(exec.Command("tmux", "split-window", "-t", windowTarget, "-c", e.WorkDir)); err != nil {
+					return firstTarget, fmt.Errorf("failed to split pane %q: %w", paneTarget, err)
+				}
+				if err := ActiveCommander.ExecSilently(exec.Command("tmux", "select-pane", "-t", paneTarget)); err != nil {
+					return firstTarget, fmt.Errorf("failed to select pane %q: %w", paneTarget, err)
+				}
+			}
+
+			spawn := e.SpawnCommand
+			if spawn == "" {
+				spawn = "claude"
+			}
+			if err := ActiveCommander.ExecSilently(exec.Command("tmux", "send-keys", "-t", paneTarget, spawn, "Enter")); err != nil {
+				return firstTarget, fmt.Errorf("failed to relaunch claude in %q: %w", paneTarget, err)
+			}
+
+			if firstTarget == "" {
+				firstTarget = windowTarget
+			}
+		}
+	}
+
+	return firstTarget, nil
+}
+
+// windowExists reports whether target ("session:window") already exists on
+// the tmux server.
+func windowExists(target string) bool {
+	err := ActiveCommander.ExecSilently(exec.Command("tmux", "list-windows", "-t", target))
+	return err == nil
+}
@@ -0,0 +1,190 @@
+package session
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveAndLoadServerSnapshot_RoundTrips(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	sessions := []Session{
+		{WorkDir: "/tmp/a", ProjectName: "a", TmuxSession: "work", WindowIndex: 0, PaneIndex: 0, Status: StatusIdle},
+		{WorkDir: "/tmp/b", ProjectName: "b", TmuxSession: "work", WindowIndex: 1, PaneIndex: 0, Status: StatusBusy},
+		{WorkDir: "/tmp/c", ProjectName: "c", Status: StatusIdle}, // detached, should be skipped
+	}
+
+	if err := SaveServerSnapshot(sessions); err != nil {
+		t.Fatalf("SaveServerSnapshot: %v", err)
+	}
+
+	path, err := serverSnapshotPath()
+	if err != nil {
+		t.Fatalf("serverSnapshotPath: %v", err)
+	}
+	if filepath.Dir(path) != filepath.Join(home, ".local", "state", "claude-tmux") {
+		t.Errorf("unexpected snapshot path: %s", path)
+	}
+
+	entries, err := LoadServerSnapshot()
+	if err != nil {
+		t.Fatalf("LoadServerSnapshot: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 restorable entries, got %d: %+v", len(entries), entries)
+	}
+	if entries[0].ProjectName != "a" || entries[1].ProjectName != "b" {
+		t.Errorf("unexpected entries: %+v", entries)
+	}
+}
+
+func TestLoadServerSnapshot_MissingFile_ReturnsNilNoError(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	entries, err := LoadServerSnapshot()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entries != nil {
+		t.Errorf("expected nil entries, got %+v", entries)
+	}
+}
+
+func TestRestore_RecreatesMissingSessionAndWindowAndRelaunchesClaude(t *testing.T) {
+	fake := &fakeCommander{
+		output: map[string]string{},
+		failFor: map[string]bool{
+			"tmux has-session -t work":    true,
+			"tmux list-windows -t work:1": true,
+		},
+	}
+	old := ActiveCommander
+	ActiveCommander = fake
+	defer func() { ActiveCommander = old }()
+
+	entries := []RestoreEntry{
+		{WorkDir: "/tmp/a", TmuxSession: "work", WindowIndex: 0},
+		{WorkDir: "/tmp/b", TmuxSession: "work", WindowIndex: 1, SpawnCommand: "claude --resume"},
+	}
+
+	target, err := Restore(entries, RestoreOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if target != "work:0" {
+		t.Errorf("expected first target %q, got %q", "work:0", target)
+	}
+
+	wantCalls := []string{
+		"tmux has-session -t work",
+		"tmux new-session -d -s work -c /tmp/a",
+		"tmux send-keys -t work:0 claude Enter",
+		"tmux list-windows -t work:1",
+		"tmux new-window -t work -c /tmp/b",
+		"tmux send-keys -t work:1 claude --resume Enter",
+	}
+	if len(fake.calls) != len(wantCalls) {
+		t.Fatalf("expected calls %v, got %v", wantCalls, fake.calls)
+	}
+	for i, want := range wantCalls {
+		if fake.calls[i] != want {
+			t.Errorf("call %d: expected %q, got %q", i, want, fake.calls[i])
+		}
+	}
+}
+
+func TestRestore_SkipsWindowThatStillExists(t *testing.T) {
+	fake := &fakeCommander{
+		output: map[string]string{},
+	}
+	old := ActiveCommander
+	ActiveCommander = fake
+	defer func() { ActiveCommander = old }()
+
+	entries := []RestoreEntry{{WorkDir: "/tmp/a", TmuxSession: "work", WindowIndex: 0}}
+
+	target, err := Restore(entries, RestoreOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if target != "" {
+		t.Errorf("expected no target recreated, got %q", target)
+	}
+
+	wantCalls := []string{
+		"tmux has-session -t work",
+		"tmux list-windows -t work:0",
+	}
+	if len(fake.calls) != len(wantCalls) {
+		t.Fatalf("expected calls %v, got %v", wantCalls, fake.calls)
+	}
+}
+
+func TestRestore_MultiPaneWindowSplitsAdditionalPanes(t *testing.T) {
+	fake := &fakeCommander{
+		output:  map[string]string{},
+		failFor: map[string]bool{"tmux has-session -t work": true},
+	}
+	old := ActiveCommander
+	ActiveCommander = fake
+	defer func() { ActiveCommander = old }()
+
+	entries := []RestoreEntry{
+		{WorkDir: "/tmp/a", TmuxSession: "work", WindowIndex: 0, PaneIndex: 0},
+		{WorkDir: "/tmp/a2", TmuxSession: "work", WindowIndex: 0, PaneIndex: 1, SpawnCommand: "claude --resume"},
+	}
+
+	target, err := Restore(entries, RestoreOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if target != "work:0" {
+		t.Errorf("expected first target %q, got %q", "work:0", target)
+	}
+
+	wantCalls := []string{
+		"tmux has-session -t work",
+		"tmux new-session -d -s work -c /tmp/a",
+		"tmux send-keys -t work:0 claude Enter",
+		"tmux split-window -t work:0 -c /tmp/a2",
+		"tmux select-pane -t work:0.1",
+		"tmux send-keys -t work:0.1 claude --resume Enter",
+	}
+	if len(fake.calls) != len(wantCalls) {
+		t.Fatalf("expected calls %v, got %v", wantCalls, fake.calls)
+	}
+	for i, want := range wantCalls {
+		if fake.calls[i] != want {
+			t.Errorf("call %d: expected %q, got %q", i, want, fake.calls[i])
+		}
+	}
+}
+
+func TestRestore_Override_KillsConflictingSessionFirst(t *testing.T) {
+	fake := &fakeCommander{output: map[string]string{}}
+	old := ActiveCommander
+	ActiveCommander = fake
+	defer func() { ActiveCommander = old }()
+
+	entries := []RestoreEntry{{WorkDir: "/tmp/a", TmuxSession: "work", WindowIndex: 0}}
+
+	if _, err := Restore(entries, RestoreOptions{Override: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantCalls := []string{
+		"tmux has-session -t work",
+		"tmux kill-session -t work",
+		"tmux new-session -d -s work -c /tmp/a",
+		"tmux send-keys -t work:0 claude Enter",
+	}
+	if len(fake.calls) != len(wantCalls) {
+		t.Fatalf("expected calls %v, got %v", wantCalls, fake.calls)
+	}
+	for i, want := range wantCalls {
+		if fake.calls[i] != want {
+			t.Errorf("call %d: expected %q, got %q", i, want, fake.calls[i])
+		}
+	}
+}
@@ -2,20 +2,22 @@ package session
 
 import (
 	"testing"
+
+	"github.com/Jevs21/claude-tmux/internal/session/procfs"
 )
 
-func TestParseProcesses(t *testing.T) {
-	psOutput := `  PID  PPID COMM
-    1     0 launchd
-  100     1 zsh
-  200   100 claude
-  300   200 claude
-  400     1 zsh
-  500   400 claude
-  600     1 vim
-`
+func TestSessionsFromProcesses(t *testing.T) {
+	processes := []procfs.Process{
+		{PID: 1, PPID: 0, Comm: "launchd"},
+		{PID: 100, PPID: 1, Comm: "zsh"},
+		{PID: 200, PPID: 100, Comm: "claude"},
+		{PID: 300, PPID: 200, Comm: "claude"},
+		{PID: 400, PPID: 1, Comm: "zsh"},
+		{PID: 500, PPID: 400, Comm: "claude"},
+		{PID: 600, PPID: 1, Comm: "vim"},
+	}
 
-	sessions := parseProcesses(psOutput)
+	sessions := sessionsFromProcesses(processes)
 
 	// Should find 2 top-level claude processes (PID 200 and 500)
 	// PID 300 is a child of PID 200 (both claude), so it should be filtered out
@@ -39,21 +41,21 @@ func TestParseProcesses(t *testing.T) {
 	}
 }
 
-func TestParseProcessesNoClaude(t *testing.T) {
-	psOutput := `  PID  PPID COMM
-    1     0 launchd
-  100     1 zsh
-  200   100 vim
-`
+func TestSessionsFromProcesses_NoClaude(t *testing.T) {
+	processes := []procfs.Process{
+		{PID: 1, PPID: 0, Comm: "launchd"},
+		{PID: 100, PPID: 1, Comm: "zsh"},
+		{PID: 200, PPID: 100, Comm: "vim"},
+	}
 
-	sessions := parseProcesses(psOutput)
+	sessions := sessionsFromProcesses(processes)
 	if len(sessions) != 0 {
 		t.Fatalf("expected 0 sessions, got %d", len(sessions))
 	}
 }
 
-func TestParseProcessesEmptyOutput(t *testing.T) {
-	sessions := parseProcesses("")
+func TestSessionsFromProcesses_EmptyInput(t *testing.T) {
+	sessions := sessionsFromProcesses(nil)
 	if len(sessions) != 0 {
 		t.Fatalf("expected 0 sessions, got %d", len(sessions))
 	}
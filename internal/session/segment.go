@@ -0,0 +1,163 @@
+package session
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"strings"
+)
+
+// crc32cTable is the Castagnoli polynomial table used for segment
+// checksums — the same one used by iSCSI, ext4, and most modern checksum
+// tooling, chosen over IEEE's crc32.ChecksumIEEE for slightly better error
+// detection on the short, text-heavy JSONL segments this package writes.
+var crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+// SegmentReader streams a rotated segment's JSONL events, transparently
+// gunzipping if the segment's name ends in .gz, so callers (search,
+// tail-from-cursor) don't need to care whether a given generation was
+// compressed.
+type SegmentReader struct {
+	file    *os.File
+	gz      *gzip.Reader
+	scanner *bufio.Scanner
+}
+
+// OpenSegmentReader opens a rotated segment (or the live head file) for
+// streaming. The caller must call Close().
+func OpenSegmentReader(path string) (*SegmentReader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &SegmentReader{file: file}
+	var reader io.Reader = file
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to open gzipped segment %q: %w", path, err)
+		}
+		r.gz = gz
+		reader = gz
+	}
+	r.scanner = bufio.NewScanner(reader)
+	return r, nil
+}
+
+// Next returns the segment's next decoded event, skipping blank and
+// malformed lines, or false once the segment is exhausted.
+func (r *SegmentReader) Next() (RawEvent, bool) {
+	for r.scanner.Scan() {
+		line := strings.TrimSpace(r.scanner.Text())
+		if line == "" {
+			continue
+		}
+		var event RawEvent
+		if json.Unmarshal([]byte(line), &event) != nil {
+			continue
+		}
+		return event, true
+	}
+	return RawEvent{}, false
+}
+
+// Close releases the segment's underlying file (and gzip reader, if any).
+func (r *SegmentReader) Close() error {
+	if r.gz != nil {
+		r.gz.Close()
+	}
+	return r.file.Close()
+}
+
+// checksumSidecarPath returns the path of archive's checksum sidecar file.
+func checksumSidecarPath(archive string) string {
+	return archive + ".sum"
+}
+
+// writeChecksumSidecar computes archive's CRC32C checksum over its
+// decompressed content and writes it as a hex sidecar file alongside it.
+func writeChecksumSidecar(archive string) error {
+	sum, err := checksumSegment(archive)
+	if err != nil {
+		return fmt.Errorf("failed to checksum archive %q: %w", archive, err)
+	}
+	if err := os.WriteFile(checksumSidecarPath(archive), []byte(sum+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write checksum sidecar for %q: %w", archive, err)
+	}
+	return nil
+}
+
+// checksumSegment computes the hex CRC32C checksum of path's decompressed
+// content, transparently gunzipping if path ends in .gz.
+func checksumSegment(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	var r io.Reader = file
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return "", err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	hasher := crc32.New(crc32cTable)
+	if _, err := io.Copy(hasher, r); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%08x", hasher.Sum32()), nil
+}
+
+// moveChecksumSidecar renames src's checksum sidecar to dst's, if one
+// exists. A missing sidecar (an archive rotated before VerifyChecksum was
+// enabled) is not an error.
+func moveChecksumSidecar(src, dst string) error {
+	srcSum := checksumSidecarPath(src)
+	if _, err := os.Stat(srcSum); err != nil {
+		return nil
+	}
+	return os.Rename(srcSum, checksumSidecarPath(dst))
+}
+
+// removeChecksumSidecar deletes archive's checksum sidecar, if one exists.
+func removeChecksumSidecar(archive string) error {
+	err := os.Remove(checksumSidecarPath(archive))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// VerifySegments walks path's rotated archives and returns the paths of any
+// whose checksum sidecar no longer matches their actual content — evidence
+// of truncation or corruption since they were rotated. Archives with no
+// sidecar (rotated without RotationPolicy.VerifyChecksum) are skipped
+// rather than reported as mismatches.
+func VerifySegments(path string) ([]string, error) {
+	var mismatched []string
+	for _, archive := range listArchives(path) {
+		want, err := os.ReadFile(checksumSidecarPath(archive))
+		if err != nil {
+			continue
+		}
+		got, err := checksumSegment(archive)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify archive %q: %w", archive, err)
+		}
+		if strings.TrimSpace(string(want)) != got {
+			mismatched = append(mismatched, archive)
+		}
+	}
+	return mismatched, nil
+}
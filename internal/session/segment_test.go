@@ -0,0 +1,120 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpenSegmentReader_TransparentlyDecompressesGzip(t *testing.T) {
+	homeDir := withShardHome(t)
+	path := filepath.Join(homeDir, ".claude-tmux", "events.d", "host-1.log")
+
+	writeShard(t, homeDir, "host-1.log",
+		`{"ts":1,"sid":"s1","event":"session-start","pid":1,"cwd":"/p","tmux":"w:0.0","tool":""}`+"\n")
+	if err := RotateLogWithPolicy(RotationPolicy{MaxBytes: 1, MaxArchives: 5, Compress: true}); err != nil {
+		t.Fatalf("RotateLogWithPolicy error: %v", err)
+	}
+	writeShard(t, homeDir, "host-1.log",
+		`{"ts":2,"sid":"s1","event":"stop","pid":1,"cwd":"/p","tmux":"w:0.0","tool":""}`+"\n")
+	if err := RotateLogWithPolicy(RotationPolicy{MaxBytes: 1, MaxArchives: 5, Compress: true}); err != nil {
+		t.Fatalf("RotateLogWithPolicy error: %v", err)
+	}
+
+	// Generation 2 should now be gzipped.
+	gzPath := path + ".2.gz"
+	if _, err := os.Stat(gzPath); err != nil {
+		t.Fatalf("expected generation 2 to be gzipped: %v", err)
+	}
+
+	reader, err := OpenSegmentReader(gzPath)
+	if err != nil {
+		t.Fatalf("OpenSegmentReader error: %v", err)
+	}
+	defer reader.Close()
+
+	event, ok := reader.Next()
+	if !ok || event.Event != "session-start" {
+		t.Fatalf("expected to transparently read the gzipped segment's event, got %+v ok=%v", event, ok)
+	}
+	if _, ok := reader.Next(); ok {
+		t.Fatalf("expected exactly one event in the segment")
+	}
+}
+
+func TestRotateLog_VerifyChecksumWritesSidecar(t *testing.T) {
+	homeDir := withShardHome(t)
+	path := filepath.Join(homeDir, ".claude-tmux", "events.d", "host-1.log")
+
+	writeShard(t, homeDir, "host-1.log", "some content\n")
+	policy := RotationPolicy{MaxBytes: 1, MaxArchives: 5, Compress: true, VerifyChecksum: true}
+	if err := RotateLogWithPolicy(policy); err != nil {
+		t.Fatalf("RotateLogWithPolicy error: %v", err)
+	}
+
+	if _, err := os.Stat(path + ".1.sum"); err != nil {
+		t.Fatalf("expected a checksum sidecar for generation 1: %v", err)
+	}
+
+	mismatched, err := VerifySegments(path)
+	if err != nil {
+		t.Fatalf("VerifySegments error: %v", err)
+	}
+	if len(mismatched) != 0 {
+		t.Fatalf("expected no mismatches on an untouched archive, got %v", mismatched)
+	}
+}
+
+func TestRotateLog_ChecksumSidecarSurvivesCompressionAndShift(t *testing.T) {
+	homeDir := withShardHome(t)
+	path := filepath.Join(homeDir, ".claude-tmux", "events.d", "host-1.log")
+
+	policy := RotationPolicy{MaxBytes: 1, MaxArchives: 5, Compress: true, VerifyChecksum: true}
+	writeShard(t, homeDir, "host-1.log", "generation one\n")
+	if err := RotateLogWithPolicy(policy); err != nil {
+		t.Fatalf("RotateLogWithPolicy error: %v", err)
+	}
+	writeShard(t, homeDir, "host-1.log", "generation two\n")
+	if err := RotateLogWithPolicy(policy); err != nil {
+		t.Fatalf("RotateLogWithPolicy error: %v", err)
+	}
+
+	if _, err := os.Stat(path + ".1.sum"); err != nil {
+		t.Fatalf("expected generation 1's sidecar to exist: %v", err)
+	}
+	if _, err := os.Stat(path + ".2.gz.sum"); err != nil {
+		t.Fatalf("expected generation 1's sidecar to have moved with it to generation 2: %v", err)
+	}
+
+	mismatched, err := VerifySegments(path)
+	if err != nil {
+		t.Fatalf("VerifySegments error: %v", err)
+	}
+	if len(mismatched) != 0 {
+		t.Fatalf("expected the shifted-and-compressed archive to still verify, got %v", mismatched)
+	}
+}
+
+func TestVerifySegments_DetectsCorruption(t *testing.T) {
+	homeDir := withShardHome(t)
+	path := filepath.Join(homeDir, ".claude-tmux", "events.d", "host-1.log")
+
+	writeShard(t, homeDir, "host-1.log", "some content\n")
+	policy := RotationPolicy{MaxBytes: 1, MaxArchives: 5, Compress: true, VerifyChecksum: true}
+	if err := RotateLogWithPolicy(policy); err != nil {
+		t.Fatalf("RotateLogWithPolicy error: %v", err)
+	}
+
+	archive := path + ".1"
+	if err := os.WriteFile(archive, []byte("corrupted content\n"), 0644); err != nil {
+		t.Fatalf("failed to corrupt archive: %v", err)
+	}
+
+	mismatched, err := VerifySegments(path)
+	if err != nil {
+		t.Fatalf("VerifySegments error: %v", err)
+	}
+	if len(mismatched) != 1 || mismatched[0] != archive {
+		t.Fatalf("expected the corrupted archive to be reported, got %v", mismatched)
+	}
+}
@@ -1,3 +1,13 @@
+// Package session discovers and manipulates Claude Code tmux sessions: it
+// maps running `claude` processes to tmux panes, tracks their activity
+// status, and drives tmux (directly or through control mode) to launch,
+// restore, or jump between them.
+//
+// This repo has no main/cmd package, so CLI subcommands like `claude-tmux
+// open`, `restore`, or `log-event` don't exist to call into; the exported
+// functions here (Launch, Restore, AppendEvent, and similar) are the
+// library entry points a future CLI (or the TUI, which already calls most
+// of them) would wire flags up to.
 package session
 
 import (
@@ -6,6 +16,9 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
+
+	"github.com/Jevs21/claude-tmux/internal/tmux"
 )
 
 // Status represents the activity state of a Claude Code session.
@@ -15,19 +28,28 @@ const (
 	StatusUnknown Status = iota
 	StatusIdle
 	StatusBusy
+	StatusWaiting
 )
 
 // Session represents a running Claude Code process mapped to a tmux pane.
 type Session struct {
+	SessionID   string
 	PID         int
 	PPID        int
+	ClaudePID   int
 	WorkDir     string
 	ProjectName string
 	TmuxTarget  string // "session:window.pane" or empty if detached
 	TmuxSession string
 	WindowIndex int
 	PaneIndex   int
+	Windows     []tmux.Window // all windows of TmuxSession, in tmux's reported order
 	Status      Status
+	Action      string // current activity label, e.g. a tool name or "Thinking…"
+	LastUpdate  time.Time
+	Repo        *Repository // VCS repository backing WorkDir, or nil if none was detected
+	WorkUnit    string      // current branch/bookmark/change for Repo, empty if Repo is nil
+	IsPrevious  bool        // true if this was the most recently attached session
 }
 
 // DisplayPath returns the working directory with the home directory replaced by ~
@@ -41,12 +63,18 @@ func (s Session) Jumpable() bool {
 	return s.TmuxTarget != ""
 }
 
-// DisplayTarget returns a formatted tmux target string for display.
+// DisplayTarget returns a formatted tmux target string for display. The
+// previously attached session (see MarkPrevious) is suffixed with "-",
+// mirroring shell "cd -" semantics, so it can be jumped back to at a glance.
 func (s Session) DisplayTarget() string {
 	if s.TmuxTarget == "" {
 		return "detached"
 	}
-	return fmt.Sprintf("%s:%d", s.TmuxSession, s.WindowIndex)
+	target := fmt.Sprintf("%s:%d", s.TmuxSession, s.WindowIndex)
+	if s.IsPrevious {
+		target += " -"
+	}
+	return target
 }
 
 // ShortenPath replaces the home directory with ~ and truncates long paths.
@@ -81,9 +109,14 @@ func ShortenPath(path string) string {
 	return shortened
 }
 
-// SortSessions sorts sessions by tmux session name, then window index.
-// Detached sessions (no tmux target) are sorted to the end.
+// SortSessions sorts sessions by repository, then tmux session name, then
+// window index. Detached sessions (no tmux target) are sorted to the end.
+// The session whose repository and work unit match the current working
+// directory's is placed first; other repositories are then ordered by name,
+// followed by sessions with no detected repository.
 func SortSessions(sessions []Session) {
+	cwdRepoRoot, cwdWorkUnit := currentRepoContext()
+
 	sort.Slice(sessions, func(i, j int) bool {
 		a, b := sessions[i], sessions[j]
 
@@ -95,6 +128,23 @@ func SortSessions(sessions []Session) {
 			return true
 		}
 
+		// The session matching the cwd's repository and work unit comes first
+		aIsCWD := isCWDSession(a, cwdRepoRoot, cwdWorkUnit)
+		bIsCWD := isCWDSession(b, cwdRepoRoot, cwdWorkUnit)
+		if aIsCWD != bIsCWD {
+			return aIsCWD
+		}
+
+		// Group by repository: sessions with a detected repo sort before
+		// sessions without one, and repos are ordered by name.
+		aRepoName, bRepoName := repoName(a), repoName(b)
+		if (aRepoName == "") != (bRepoName == "") {
+			return aRepoName != ""
+		}
+		if aRepoName != bRepoName {
+			return aRepoName < bRepoName
+		}
+
 		// Sort by tmux session name
 		if a.TmuxSession != b.TmuxSession {
 			return a.TmuxSession < b.TmuxSession
@@ -104,3 +154,41 @@ func SortSessions(sessions []Session) {
 		return a.WindowIndex < b.WindowIndex
 	})
 }
+
+// currentRepoContext detects the repository and work unit for the current
+// working directory, returning empty strings if detection fails.
+func currentRepoContext() (repoRoot, workUnit string) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", ""
+	}
+
+	repo, err := DetectRepository(cwd)
+	if err != nil {
+		return "", ""
+	}
+
+	unit, err := repo.CurrentWorkUnit()
+	if err != nil {
+		return "", ""
+	}
+
+	return repo.RootDir(), unit
+}
+
+// isCWDSession reports whether s belongs to the repository and work unit
+// identified by cwdRepoRoot/cwdWorkUnit.
+func isCWDSession(s Session, cwdRepoRoot, cwdWorkUnit string) bool {
+	if cwdRepoRoot == "" || s.Repo == nil {
+		return false
+	}
+	return s.Repo.RootDir() == cwdRepoRoot && s.WorkUnit == cwdWorkUnit
+}
+
+// repoName returns the session's repository name, or "" if it has none.
+func repoName(s Session) string {
+	if s.Repo == nil {
+		return ""
+	}
+	return s.Repo.Name()
+}
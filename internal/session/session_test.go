@@ -3,6 +3,8 @@ package session
 import (
 	"os"
 	"testing"
+
+	"github.com/Jevs21/claude-tmux/internal/tmux"
 )
 
 func TestShortenPath(t *testing.T) {
@@ -83,6 +85,16 @@ func TestSessionDisplayTarget(t *testing.T) {
 	}
 }
 
+func TestSessionDisplayTarget_GappedWindowIndexes(t *testing.T) {
+	// tmux renumbers and gaps window indexes after kills/moves, so a
+	// session's lowest window can be any index, not just 0.
+	windows := []tmux.Window{{Index: 2, Name: "one"}, {Index: 5, Name: "two"}, {Index: 7, Name: "three", Active: true}}
+	s := Session{TmuxSession: "work", TmuxTarget: "work:7.0", WindowIndex: 7, Windows: windows}
+	if s.DisplayTarget() != "work:7" {
+		t.Errorf("expected 'work:7', got %q", s.DisplayTarget())
+	}
+}
+
 func TestShortenPath_EdgeCases(t *testing.T) {
 	homeDir, _ := os.UserHomeDir()
 
@@ -194,4 +206,49 @@ func TestSortSessions_EdgeCases(t *testing.T) {
 			}
 		}
 	})
+
+	t.Run("sessions with a repo are grouped ahead of those without", func(t *testing.T) {
+		repo := &Repository{backend: gitVCS{}, rootDir: "/tmp/zzz-repo"}
+		sessions := []Session{
+			{SessionID: "norepo", TmuxSession: "alpha", TmuxTarget: "alpha:0.0"},
+			{SessionID: "hasrepo", TmuxSession: "zeta", TmuxTarget: "zeta:0.0", Repo: repo, WorkUnit: "main"},
+		}
+		SortSessions(sessions)
+		if sessions[0].SessionID != "hasrepo" {
+			t.Errorf("expected repo session first, got %q", sessions[0].SessionID)
+		}
+	})
+
+	t.Run("repos grouped by name even across differing tmux session names", func(t *testing.T) {
+		repoA := &Repository{backend: gitVCS{}, rootDir: "/tmp/alpha-repo"}
+		repoB := &Repository{backend: gitVCS{}, rootDir: "/tmp/beta-repo"}
+		sessions := []Session{
+			{SessionID: "b1", TmuxSession: "z", TmuxTarget: "z:0.0", Repo: repoB, WorkUnit: "main"},
+			{SessionID: "a1", TmuxSession: "a", TmuxTarget: "a:1.0", WindowIndex: 1, Repo: repoA, WorkUnit: "main"},
+			{SessionID: "a0", TmuxSession: "a", TmuxTarget: "a:0.0", WindowIndex: 0, Repo: repoA, WorkUnit: "main"},
+		}
+		SortSessions(sessions)
+		expectedOrder := []string{"a0", "a1", "b1"}
+		for i, expectedID := range expectedOrder {
+			if sessions[i].SessionID != expectedID {
+				t.Errorf("position %d: expected %q, got %q", i, expectedID, sessions[i].SessionID)
+			}
+		}
+	})
+
+	t.Run("sorts stably when a session's windows are gapped and non-zero", func(t *testing.T) {
+		windows := []tmux.Window{{Index: 2, Name: "one"}, {Index: 5, Name: "two", Active: true}, {Index: 7, Name: "three"}}
+		sessions := []Session{
+			{SessionID: "hi", TmuxSession: "work", TmuxTarget: "work:7.0", WindowIndex: 7, Windows: windows},
+			{SessionID: "lo", TmuxSession: "work", TmuxTarget: "work:2.0", WindowIndex: 2, Windows: windows},
+			{SessionID: "mid", TmuxSession: "work", TmuxTarget: "work:5.0", WindowIndex: 5, Windows: windows},
+		}
+		SortSessions(sessions)
+		expectedOrder := []string{"lo", "mid", "hi"}
+		for i, expectedID := range expectedOrder {
+			if sessions[i].SessionID != expectedID {
+				t.Errorf("position %d: expected %q, got %q", i, expectedID, sessions[i].SessionID)
+			}
+		}
+	})
 }
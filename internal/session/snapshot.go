@@ -0,0 +1,213 @@
+package session
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// shardCheckpoint records how far a shard had been read the last time the
+// snapshot was written, so the next read can seek straight to new bytes
+// instead of reparsing the whole file.
+type shardCheckpoint struct {
+	Offset  int64  `json:"offset"`
+	ModTime int64  `json:"mod_time"` // UnixNano, informational only
+	Inode   uint64 `json:"inode,omitempty"`
+}
+
+// sessionSnapshot is the on-disk checkpoint: the live-session map derived
+// from events read so far, plus where each shard had been read up to.
+type sessionSnapshot struct {
+	Sessions map[string]*Session        `json:"sessions"`
+	Shards   map[string]shardCheckpoint `json:"shards"`
+}
+
+// snapshotPath returns the path of the session-state checkpoint file.
+func snapshotPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".claude-tmux", "sessions.snapshot")
+}
+
+// loadSnapshot reads the checkpoint file. Any problem reading or parsing it
+// — missing file, truncated write, a format from a future version — is
+// treated as "no snapshot" rather than an error: the caller falls back to a
+// full replay and overwrites it.
+func loadSnapshot() *sessionSnapshot {
+	data, err := os.ReadFile(snapshotPath())
+	if err != nil {
+		return nil
+	}
+	var snap sessionSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil
+	}
+	if snap.Sessions == nil || snap.Shards == nil {
+		return nil
+	}
+	return &snap
+}
+
+// saveSnapshot writes the checkpoint file. Failures are ignored: the next
+// read will simply do a full replay again.
+func saveSnapshot(sessionMap map[string]*Session, shards map[string]shardCheckpoint) {
+	path := snapshotPath()
+	if path == "" {
+		return
+	}
+	data, err := json.Marshal(sessionSnapshot{Sessions: sessionMap, Shards: shards})
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0644)
+}
+
+// checkpointFor builds the checkpoint recorded for a shard read up to offset.
+func checkpointFor(info os.FileInfo, offset int64) shardCheckpoint {
+	ck := shardCheckpoint{Offset: offset, ModTime: info.ModTime().UnixNano()}
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		ck.Inode = stat.Ino
+	}
+	return ck
+}
+
+// snapshotCoversPaths reports whether snap can be used as the base for an
+// incremental read of paths. A shard the snapshot hasn't seen before is
+// fine — it is simply read from offset 0. A shard that has shrunk below its
+// recorded offset, or whose inode no longer matches, means it was rotated
+// or replaced out from under us, and the whole snapshot must be discarded
+// in favor of a full replay.
+func snapshotCoversPaths(snap *sessionSnapshot, paths []string, stats map[string]os.FileInfo) bool {
+	for _, p := range paths {
+		ck, ok := snap.Shards[p]
+		if !ok {
+			continue
+		}
+		info := stats[p]
+		if info.Size() < ck.Offset {
+			return false
+		}
+		if ck.Inode != 0 {
+			if stat, ok := info.Sys().(*syscall.Stat_t); ok && stat.Ino != ck.Inode {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// sessionMapFromLog derives the current session map from the event log
+// shards, using the on-disk snapshot to replay only newly appended bytes
+// when possible. It always leaves an up-to-date snapshot behind for the
+// next call.
+func sessionMapFromLog(paths []string, stats map[string]os.FileInfo) (map[string]*Session, error) {
+	if snap := loadSnapshot(); snap != nil && snapshotCoversPaths(snap, paths, stats) {
+		sessionMap, shards, err := applySnapshotDelta(snap, paths, stats)
+		if err == nil {
+			saveSnapshot(sessionMap, shards)
+			return sessionMap, nil
+		}
+		// A shard disappeared or became unreadable mid-delta — fall back to
+		// a full replay below rather than surface a transient error.
+	}
+
+	// A cold start or an invalidated snapshot both mean recent history might
+	// only exist in a shard's rotated archives now, not the live file alone —
+	// include it so an active session doesn't just vanish across a rotation
+	// boundary.
+	byPath := make(map[string][]RawEvent, len(paths))
+	for _, p := range paths {
+		byPath[p] = shardEventsWithHistory(p)
+	}
+
+	sessionMap := make(map[string]*Session)
+	applyEvents(sessionMap, mergeEventsByPath(byPath))
+
+	shards := make(map[string]shardCheckpoint, len(paths))
+	for _, p := range paths {
+		shards[p] = checkpointFor(stats[p], stats[p].Size())
+	}
+	saveSnapshot(sessionMap, shards)
+
+	return sessionMap, nil
+}
+
+// applySnapshotDelta replays only the bytes appended to each shard since
+// snap was taken, applying them on top of snap's cached session map.
+func applySnapshotDelta(snap *sessionSnapshot, paths []string, stats map[string]os.FileInfo) (map[string]*Session, map[string]shardCheckpoint, error) {
+	sessionMap := make(map[string]*Session, len(snap.Sessions))
+	for id, s := range snap.Sessions {
+		cp := *s
+		sessionMap[id] = &cp
+	}
+
+	byPath := make(map[string][]RawEvent, len(paths))
+	shards := make(map[string]shardCheckpoint, len(paths))
+
+	for _, p := range paths {
+		startOffset := int64(0)
+		if ck, ok := snap.Shards[p]; ok {
+			startOffset = ck.Offset
+		}
+
+		events, newOffset, err := readEventsFrom(p, startOffset)
+		if err != nil {
+			return nil, nil, err
+		}
+		byPath[p] = events
+		shards[p] = checkpointFor(stats[p], newOffset)
+	}
+
+	applyEvents(sessionMap, mergeEventsByPath(byPath))
+
+	return sessionMap, shards, nil
+}
+
+// readEventsFrom reads and decodes the JSONL events appended to path after
+// startOffset. It returns the decoded events along with the offset of the
+// last complete line consumed — a trailing line with no terminating newline
+// yet (a writer caught mid-append) is left unconsumed so it's picked up
+// whole on a later call.
+func readEventsFrom(path string, startOffset int64) ([]RawEvent, int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, startOffset, err
+	}
+	defer file.Close()
+
+	if startOffset > 0 {
+		if _, err := file.Seek(startOffset, io.SeekStart); err != nil {
+			return nil, startOffset, err
+		}
+	}
+
+	reader := bufio.NewReader(file)
+	offset := startOffset
+	var events []RawEvent
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if err != io.EOF {
+				return nil, offset, err
+			}
+			// EOF: if line is non-empty it's a partial trailing line with no
+			// newline yet — leave it unconsumed for next time.
+			break
+		}
+		offset += int64(len(line))
+		if trimmed := strings.TrimSpace(line); trimmed != "" {
+			var event RawEvent
+			if json.Unmarshal([]byte(trimmed), &event) == nil {
+				events = append(events, event)
+			}
+		}
+	}
+
+	return events, offset, nil
+}
@@ -0,0 +1,212 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func readSnapshotFile(t *testing.T, homeDir string) sessionSnapshot {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(homeDir, ".claude-tmux", "sessions.snapshot"))
+	if err != nil {
+		t.Fatalf("failed to read snapshot file: %v", err)
+	}
+	var snap sessionSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		t.Fatalf("failed to parse snapshot file: %v", err)
+	}
+	return snap
+}
+
+func TestReadSessions_ColdStartWritesSnapshot(t *testing.T) {
+	homeDir := withShardHome(t)
+	currentPID := os.Getpid()
+
+	shardPath := filepath.Join(homeDir, ".claude-tmux", "events.d", "host-1.log")
+	writeShard(t, homeDir, "host-1.log",
+		fmt.Sprintf(`{"ts":1000,"sid":"s1","event":"session-start","pid":%d,"cwd":"/proj","tmux":"work:0.0","tool":""}`, currentPID)+"\n",
+	)
+
+	if _, err := os.Stat(filepath.Join(homeDir, ".claude-tmux", "sessions.snapshot")); !os.IsNotExist(err) {
+		t.Fatalf("expected no snapshot before the first read")
+	}
+
+	sessions, err := ReadSessions()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sessions) != 1 || sessions[0].SessionID != "s1" {
+		t.Fatalf("expected session 's1', got %+v", sessions)
+	}
+
+	snap := readSnapshotFile(t, homeDir)
+	info, err := os.Stat(shardPath)
+	if err != nil {
+		t.Fatalf("failed to stat shard: %v", err)
+	}
+	if snap.Shards[shardPath].Offset != info.Size() {
+		t.Errorf("expected checkpoint offset %d to match shard size %d", snap.Shards[shardPath].Offset, info.Size())
+	}
+}
+
+func TestReadSessions_IncrementalReadAcrossTwoCalls(t *testing.T) {
+	homeDir := withShardHome(t)
+	currentPID := os.Getpid()
+
+	shardPath := filepath.Join(homeDir, ".claude-tmux", "events.d", "host-1.log")
+	writeShard(t, homeDir, "host-1.log",
+		fmt.Sprintf(`{"ts":1000,"sid":"s1","event":"session-start","pid":%d,"cwd":"/proj","tmux":"work:0.0","tool":""}`, currentPID)+"\n",
+	)
+
+	first, err := ReadSessions()
+	if err != nil {
+		t.Fatalf("unexpected error on first read: %v", err)
+	}
+	if len(first) != 1 || first[0].Status != StatusIdle {
+		t.Fatalf("expected one idle session after first read, got %+v", first)
+	}
+	firstOffset := readSnapshotFile(t, homeDir).Shards[shardPath].Offset
+
+	// Append a new event directly, as a running hook process would.
+	file, err := os.OpenFile(shardPath, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("failed to open shard for append: %v", err)
+	}
+	appended := fmt.Sprintf(`{"ts":1001,"sid":"s1","event":"user-prompt-submit","pid":%d,"cwd":"/proj","tmux":"work:0.0","tool":""}`, currentPID) + "\n"
+	if _, err := file.WriteString(appended); err != nil {
+		t.Fatalf("failed to append to shard: %v", err)
+	}
+	file.Close()
+
+	second, err := ReadSessions()
+	if err != nil {
+		t.Fatalf("unexpected error on second read: %v", err)
+	}
+	if len(second) != 1 || second[0].Status != StatusBusy {
+		t.Fatalf("expected the incremental read to pick up the appended event, got %+v", second)
+	}
+
+	info, err := os.Stat(shardPath)
+	if err != nil {
+		t.Fatalf("failed to stat shard: %v", err)
+	}
+	secondOffset := readSnapshotFile(t, homeDir).Shards[shardPath].Offset
+	if secondOffset != info.Size() {
+		t.Errorf("expected checkpoint offset to advance to %d, got %d", info.Size(), secondOffset)
+	}
+	if secondOffset <= firstOffset {
+		t.Errorf("expected checkpoint offset to grow past %d, got %d", firstOffset, secondOffset)
+	}
+}
+
+func TestReadSessions_RotationInvalidatesSnapshot(t *testing.T) {
+	homeDir := withShardHome(t)
+	currentPID := os.Getpid()
+
+	shardPath := filepath.Join(homeDir, ".claude-tmux", "events.d", "host-1.log")
+	writeShard(t, homeDir, "host-1.log",
+		fmt.Sprintf(`{"ts":1000,"sid":"old","event":"session-start","pid":%d,"cwd":"/proj/old","tmux":"work:0.0","tool":""}`, currentPID)+"\n"+
+			fmt.Sprintf(`{"ts":1001,"sid":"old","event":"stop","pid":%d,"cwd":"/proj/old","tmux":"work:0.0","tool":""}`, currentPID)+"\n",
+	)
+
+	if _, err := ReadSessions(); err != nil {
+		t.Fatalf("unexpected error on first read: %v", err)
+	}
+
+	// Simulate RotateLog trimming the shard down to a shorter, rewritten file.
+	writeShard(t, homeDir, "host-1.log",
+		fmt.Sprintf(`{"ts":2000,"sid":"new","event":"session-start","pid":%d,"cwd":"/proj/new","tmux":"dev:0.0","tool":""}`, currentPID)+"\n",
+	)
+
+	sessions, err := ReadSessions()
+	if err != nil {
+		t.Fatalf("unexpected error on second read: %v", err)
+	}
+	if len(sessions) != 1 || sessions[0].SessionID != "new" {
+		t.Fatalf("expected only the rotated shard's session to survive, got %+v", sessions)
+	}
+
+	info, err := os.Stat(shardPath)
+	if err != nil {
+		t.Fatalf("failed to stat shard: %v", err)
+	}
+	offset := readSnapshotFile(t, homeDir).Shards[shardPath].Offset
+	if offset != info.Size() {
+		t.Errorf("expected checkpoint to be rebuilt against the rotated file size %d, got %d", info.Size(), offset)
+	}
+}
+
+func TestReadSessions_ConsumesRotatedArchivesAcrossBoundary(t *testing.T) {
+	homeDir := withShardHome(t)
+	currentPID := os.Getpid()
+
+	shardPath := filepath.Join(homeDir, ".claude-tmux", "events.d", "host-1.log")
+	writeShard(t, homeDir, "host-1.log",
+		fmt.Sprintf(`{"ts":1000,"sid":"s1","event":"session-start","pid":%d,"cwd":"/proj","tmux":"work:0.0","tool":""}`, currentPID)+"\n",
+	)
+
+	// A first read builds a snapshot checkpointed at the live file's size.
+	if _, err := ReadSessions(); err != nil {
+		t.Fatalf("unexpected error on first read: %v", err)
+	}
+
+	// Rotate the shard out from under the snapshot: the live file becomes
+	// smaller (fresh/empty) than the checkpointed offset, and the session's
+	// only "session-start" event now lives solely in the .1 archive.
+	policy := RotationPolicy{MaxBytes: 1, MaxArchives: 5, Compress: true}
+	if err := RotateLogWithPolicy(policy); err != nil {
+		t.Fatalf("RotateLogWithPolicy error: %v", err)
+	}
+	if _, err := os.Stat(shardPath + ".1"); err != nil {
+		t.Fatalf("expected the shard to have been archived: %v", err)
+	}
+
+	// A still-running hook process appends its next event to the fresh file.
+	file, err := os.OpenFile(shardPath, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("failed to open shard for append: %v", err)
+	}
+	appended := fmt.Sprintf(`{"ts":1001,"sid":"s1","event":"user-prompt-submit","pid":%d,"cwd":"/proj","tmux":"work:0.0","tool":""}`, currentPID) + "\n"
+	if _, err := file.WriteString(appended); err != nil {
+		t.Fatalf("failed to append to shard: %v", err)
+	}
+	file.Close()
+
+	sessions, err := ReadSessions()
+	if err != nil {
+		t.Fatalf("unexpected error on read across rotation boundary: %v", err)
+	}
+	if len(sessions) != 1 || sessions[0].SessionID != "s1" {
+		t.Fatalf("expected the session's identity to survive rotation by reading its archive, got %+v", sessions)
+	}
+	if sessions[0].Status != StatusBusy {
+		t.Errorf("expected the post-rotation event to still apply on top of the archived session-start, got status %v", sessions[0].Status)
+	}
+	if sessions[0].WorkDir != "/proj" {
+		t.Errorf("expected WorkDir '/proj' reconstructed from the archived session-start, got %q", sessions[0].WorkDir)
+	}
+}
+
+func TestReadSessions_CorruptSnapshotDiscardedSilently(t *testing.T) {
+	cleanup := writeTestLog(t, []string{
+		fmt.Sprintf(`{"ts":1000,"sid":"s1","event":"session-start","pid":%d,"cwd":"/proj","tmux":"work:0.0","tool":""}`, os.Getpid()),
+	})
+	defer cleanup()
+
+	homeDir := os.Getenv("HOME")
+	snapDir := filepath.Join(homeDir, ".claude-tmux")
+	if err := os.WriteFile(filepath.Join(snapDir, "sessions.snapshot"), []byte("not json at all{{{"), 0644); err != nil {
+		t.Fatalf("failed to write corrupt snapshot: %v", err)
+	}
+
+	sessions, err := ReadSessions()
+	if err != nil {
+		t.Fatalf("expected a corrupt snapshot to be discarded silently, got error: %v", err)
+	}
+	if len(sessions) != 1 || sessions[0].SessionID != "s1" {
+		t.Fatalf("expected the full replay fallback to still find the session, got %+v", sessions)
+	}
+}
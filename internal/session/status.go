@@ -1,8 +1,10 @@
 package session
 
 import (
+	"fmt"
 	"os/exec"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"unicode"
@@ -51,12 +53,109 @@ func CaptureStatuses(sessions []Session) {
 
 // capturePaneContent runs tmux capture-pane to get the visible content of a pane.
 func capturePaneContent(tmuxTarget string) string {
-	cmd := exec.Command("tmux", "capture-pane", "-t", tmuxTarget, "-p")
-	output, err := cmd.Output()
+	output, err := queryTmux("capture-pane", "-t", tmuxTarget, "-p")
 	if err != nil {
 		return ""
 	}
-	return string(output)
+	return output
+}
+
+// CapturePaneContent captures tmuxTarget's current visible pane content, for
+// callers that need to match it against arbitrary text (e.g. the TUI's
+// auto-confirm allowlist) rather than just derive a Status from it.
+func CapturePaneContent(tmuxTarget string) string {
+	return capturePaneContent(tmuxTarget)
+}
+
+// SubmitKeys sends keys to tmuxTarget's pane followed by Enter, for
+// answering an interactive prompt (e.g. a numbered permission menu). It goes
+// through ActiveCommander, as CapturePaneContent does when no
+// ActiveController is set, so tests that substitute a fake commander (and
+// leave ActiveController nil) observe both the read and the write.
+func SubmitKeys(tmuxTarget string, keys ...string) error {
+	args := append([]string{"send-keys", "-t", tmuxTarget}, keys...)
+	args = append(args, "Enter")
+	if err := ActiveCommander.ExecSilently(exec.Command("tmux", args...)); err != nil {
+		return fmt.Errorf("tmux send-keys failed: %w", err)
+	}
+	return nil
+}
+
+// WaitingOption is one numbered choice found in a StatusWaiting session's
+// pane content, e.g. Number 1, Text "Yes" from a line like "❯ 1. Yes".
+type WaitingOption struct {
+	Number int
+	Text   string
+}
+
+// CaptureWaitingOptions captures tmuxTarget's pane content and returns the
+// numbered options from its interactive menu, in number order, using the
+// same line format detectNumberedOptions and detectPromptQuestion already
+// parse to decide a session is StatusWaiting.
+func CaptureWaitingOptions(tmuxTarget string) []WaitingOption {
+	return parseWaitingOptions(capturePaneContent(tmuxTarget))
+}
+
+// parseWaitingOptions extracts the numbered options out of paneContent.
+func parseWaitingOptions(paneContent string) []WaitingOption {
+	if paneContent == "" {
+		return nil
+	}
+
+	seen := make(map[int]bool)
+	var options []WaitingOption
+	for _, line := range strings.Split(paneContent, "\n") {
+		line = sanitizeLine(line)
+		number, _, matched := parseNumberedOption(line)
+		if !matched || seen[number] {
+			continue
+		}
+		seen[number] = true
+		options = append(options, WaitingOption{Number: number, Text: numberedOptionText(line)})
+	}
+
+	sort.Slice(options, func(i, j int) bool { return options[i].Number < options[j].Number })
+	return options
+}
+
+// numberedOptionText returns the label portion of a numbered-option line
+// (e.g. "Yes" from " ❯ 1. Yes"), stripping the same prefix and "N. " pattern
+// parseNumberedOption recognizes.
+func numberedOptionText(line string) string {
+	trimmed := strings.TrimLeftFunc(line, unicode.IsSpace)
+	trimmed = strings.TrimPrefix(trimmed, "❯")
+	trimmed = strings.TrimLeftFunc(trimmed, unicode.IsSpace)
+
+	dotIndex := strings.Index(trimmed, ". ")
+	if dotIndex < 1 {
+		return ""
+	}
+	return strings.TrimSpace(trimmed[dotIndex+2:])
+}
+
+// YesOption returns the option number whose text answers "yes" among
+// options, if any.
+func YesOption(options []WaitingOption) (int, bool) {
+	return findOptionByAnswer(options, "yes")
+}
+
+// NoOption returns the option number whose text answers "no" among options,
+// if any.
+func NoOption(options []WaitingOption) (int, bool) {
+	return findOptionByAnswer(options, "no")
+}
+
+// findOptionByAnswer looks for an option whose text starts with answer
+// ("yes" or "no"), allowing the longer explanatory text Claude Code's own
+// prompts often append (e.g. "No, and tell Claude what to do differently").
+func findOptionByAnswer(options []WaitingOption, answer string) (int, bool) {
+	for _, opt := range options {
+		text := strings.ToLower(strings.TrimSpace(opt.Text))
+		if text == answer || strings.HasPrefix(text, answer+",") || strings.HasPrefix(text, answer+" ") {
+			return opt.Number, true
+		}
+	}
+	return 0, false
 }
 
 // parseNumberedOption checks if a line represents a numbered option (e.g., "1. Yes"
@@ -156,3 +156,46 @@ func TestDetectStatus_BusyTakesPrecedenceOverWaiting(t *testing.T) {
 		t.Errorf("expected StatusBusy to take precedence over waiting, got %d", status)
 	}
 }
+
+func TestParseWaitingOptions_RealClaudeCodePermissionPrompt(t *testing.T) {
+	paneContent := " Do you want to proceed?\n" +
+		" ❯ 1. Yes\n" +
+		"   2. Yes, and don't ask again for git add commands\n" +
+		"   3. No\n"
+
+	options := parseWaitingOptions(paneContent)
+	if len(options) != 3 {
+		t.Fatalf("expected 3 options, got %d: %+v", len(options), options)
+	}
+	if options[0] != (WaitingOption{Number: 1, Text: "Yes"}) {
+		t.Errorf("unexpected option 1: %+v", options[0])
+	}
+	if options[2] != (WaitingOption{Number: 3, Text: "No"}) {
+		t.Errorf("unexpected option 3: %+v", options[2])
+	}
+
+	yes, ok := YesOption(options)
+	if !ok || yes != 1 {
+		t.Errorf("expected YesOption 1, got %d, %v", yes, ok)
+	}
+	no, ok := NoOption(options)
+	if !ok || no != 3 {
+		t.Errorf("expected NoOption 3, got %d, %v", no, ok)
+	}
+}
+
+func TestParseWaitingOptions_NoMenu(t *testing.T) {
+	if options := parseWaitingOptions("just some output\n❯ \n"); options != nil {
+		t.Errorf("expected no options, got %+v", options)
+	}
+}
+
+func TestYesNoOption_NotFound(t *testing.T) {
+	options := []WaitingOption{{Number: 1, Text: "Allow once"}, {Number: 2, Text: "Allow always"}}
+	if _, ok := YesOption(options); ok {
+		t.Error("expected no YesOption among non-yes/no options")
+	}
+	if _, ok := NoOption(options); ok {
+		t.Error("expected no NoOption among non-yes/no options")
+	}
+}
@@ -6,6 +6,9 @@ import (
 	"os/exec"
 	"strconv"
 	"strings"
+
+	"github.com/Jevs21/claude-tmux/internal/session/procfs"
+	"github.com/Jevs21/claude-tmux/internal/tmux"
 )
 
 // paneInfo holds the tmux pane metadata for a single pane.
@@ -42,6 +45,9 @@ func MapPanes(sessions []Session) []Session {
 				info.WindowIndex,
 				info.PaneIndex,
 			)
+			if windows, err := tmux.ListWindows(info.SessionName); err == nil {
+				sessions[i].Windows = windows
+			}
 		}
 	}
 
@@ -50,17 +56,14 @@ func MapPanes(sessions []Session) []Session {
 
 // getTmuxPanes queries tmux for all panes and returns a map of panePID → paneInfo.
 func getTmuxPanes() (map[int]paneInfo, error) {
-	cmd := exec.Command(
-		"tmux", "list-panes", "-a",
-		"-F", "#{pane_pid} #{session_name} #{window_index} #{pane_index}",
-	)
-	output, err := cmd.Output()
+	output, err := queryTmux("list-panes", "-a",
+		"-F", "#{pane_pid} #{session_name} #{window_index} #{pane_index}")
 	if err != nil {
 		return nil, fmt.Errorf("tmux list-panes failed: %w", err)
 	}
 
 	paneMap := make(map[int]paneInfo)
-	scanner := bufio.NewScanner(strings.NewReader(string(output)))
+	scanner := bufio.NewScanner(strings.NewReader(output))
 	for scanner.Scan() {
 		line := scanner.Text()
 		fields := strings.Fields(line)
@@ -91,35 +94,50 @@ func getTmuxPanes() (map[int]paneInfo, error) {
 	return paneMap, nil
 }
 
-// getProcessTree returns a map of PID → PPID for all processes.
-func getProcessTree() (map[int]int, error) {
-	cmd := exec.Command("ps", "-axo", "pid,ppid")
-	output, err := cmd.Output()
-	if err != nil {
-		return nil, fmt.Errorf("ps failed: %w", err)
+// queryTmux runs a tmux subcommand through ActiveController when one is
+// set, reusing its long-lived control connection instead of forking a new
+// tmux process, and falls back to ActiveCommander's one-shot exec otherwise.
+func queryTmux(args ...string) (string, error) {
+	if ActiveController != nil {
+		lines, err := ActiveController.Command(joinTmuxCommand(args))
+		if err != nil {
+			return "", err
+		}
+		return strings.Join(lines, "\n"), nil
 	}
+	return ActiveCommander.Exec(exec.Command("tmux", args...))
+}
 
-	tree := make(map[int]int)
-	scanner := bufio.NewScanner(strings.NewReader(string(output)))
-	for scanner.Scan() {
-		line := scanner.Text()
-		fields := strings.Fields(line)
-		if len(fields) < 2 {
-			continue
-		}
+// joinTmuxCommand renders args as a single line the way tmux's command
+// prompt expects, quoting any argument (such as a -F format string) that
+// contains whitespace so it survives tmux's own parsing intact.
+func joinTmuxCommand(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = quoteTmuxArg(arg)
+	}
+	return strings.Join(quoted, " ")
+}
 
-		pid, err := strconv.Atoi(fields[0])
-		if err != nil {
-			continue
-		}
-		ppid, err := strconv.Atoi(fields[1])
-		if err != nil {
-			continue
-		}
+func quoteTmuxArg(arg string) string {
+	if arg != "" && !strings.ContainsAny(arg, " \t\"") {
+		return arg
+	}
+	return `"` + strings.ReplaceAll(arg, `"`, `\"`) + `"`
+}
 
-		tree[pid] = ppid
+// getProcessTree returns a map of PID → PPID for all processes, read via
+// procfs.List rather than shelling out to ps.
+func getProcessTree() (map[int]int, error) {
+	processes, err := procfs.List()
+	if err != nil {
+		return nil, fmt.Errorf("list processes: %w", err)
 	}
 
+	tree := make(map[int]int, len(processes))
+	for _, p := range processes {
+		tree[p.PID] = p.PPID
+	}
 	return tree, nil
 }
 
@@ -0,0 +1,53 @@
+package session
+
+import "testing"
+
+// queryTmux's control-mode path (ActiveController != nil) delegates
+// straight to *tmux.Controller.Command, which is exercised by
+// internal/tmux's own tests; these only cover the fallback path and the
+// quoting helper.
+func TestQueryTmux_FallsBackToActiveCommanderWhenNoController(t *testing.T) {
+	fake := &fakeCommander{output: map[string]string{
+		"tmux list-panes -a": "12345 work 0 0\n",
+	}}
+	oldCommander, oldController := ActiveCommander, ActiveController
+	ActiveCommander, ActiveController = fake, nil
+	defer func() { ActiveCommander, ActiveController = oldCommander, oldController }()
+
+	out, err := queryTmux("list-panes", "-a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out != "12345 work 0 0\n" {
+		t.Errorf("expected %q, got %q", "12345 work 0 0\n", out)
+	}
+	if len(fake.calls) != 1 || fake.calls[0] != "tmux list-panes -a" {
+		t.Errorf("expected a single %q call, got %v", "tmux list-panes -a", fake.calls)
+	}
+}
+
+func TestJoinTmuxCommand_QuotesArgsContainingWhitespace(t *testing.T) {
+	got := joinTmuxCommand([]string{"list-panes", "-a", "-F", "#{pane_pid} #{session_name}"})
+	want := `list-panes -a -F "#{pane_pid} #{session_name}"`
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestJoinTmuxCommand_LeavesPlainArgsUnquoted(t *testing.T) {
+	got := joinTmuxCommand([]string{"capture-pane", "-t", "work:0.0", "-p"})
+	want := "capture-pane -t work:0.0 -p"
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestWatchControllerNotifications_NilWhenNoController(t *testing.T) {
+	old := ActiveController
+	ActiveController = nil
+	defer func() { ActiveController = old }()
+
+	if ch := WatchControllerNotifications(); ch != nil {
+		t.Errorf("expected a nil channel when ActiveController is unset, got %v", ch)
+	}
+}
@@ -0,0 +1,76 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/Jevs21/claude-tmux/internal/tail"
+)
+
+// HookSentinelPath returns the file tmux's refresh hooks (installed by
+// tmux.InstallRefreshHooks) append a line to on pane-focus-in,
+// session-created, session-closed, and window-linked. WatchForChanges tails
+// it alongside the event shards, so a hook firing looks just like a new
+// event line arriving.
+func HookSentinelPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".claude-tmux", "hooks.sentinel"), nil
+}
+
+// WatchForChanges tails every current event shard plus the tmux hook
+// sentinel file, delivering on the returned channel whenever any of them
+// gains a new line. Sends are coalesced to a single pending signal, since a
+// caller only needs to know that something changed, not how many times. The
+// channel is closed once ctx is canceled and every watch goroutine exits.
+//
+// Shards created after WatchForChanges starts (a brand new claude-tmux
+// session, say) aren't picked up until the process restarts; the caller's
+// safety-net tick covers that gap.
+func WatchForChanges(ctx context.Context) (<-chan struct{}, error) {
+	paths, err := shardPaths()
+	if err != nil {
+		return nil, err
+	}
+
+	sentinel, err := HookSentinelPath()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(sentinel), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", filepath.Dir(sentinel), err)
+	}
+	paths = append(paths, sentinel)
+
+	changed := make(chan struct{}, 1)
+	var wg sync.WaitGroup
+
+	for _, path := range paths {
+		lines, err := tail.Tail(ctx, path)
+		if err != nil {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range lines {
+				select {
+				case changed <- struct{}{}:
+				default:
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(changed)
+	}()
+
+	return changed, nil
+}
@@ -0,0 +1,76 @@
+package session
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWatchForChanges_SignalsOnShardWrite(t *testing.T) {
+	homeDir := withShardHome(t)
+	writeShard(t, homeDir, "host-1.log", "")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	changed, err := WatchForChanges(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	shardPath := filepath.Join(homeDir, ".claude-tmux", "events.d", "host-1.log")
+	appendLine(t, shardPath, `{"event":"started"}`+"\n")
+
+	select {
+	case <-changed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a change signal after the shard was written to")
+	}
+}
+
+func TestWatchForChanges_SignalsOnHookSentinelWrite(t *testing.T) {
+	homeDir := withShardHome(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	changed, err := WatchForChanges(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sentinel, err := HookSentinelPath()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(sentinel, homeDir) {
+		t.Fatalf("expected sentinel path %q under HOME %q", sentinel, homeDir)
+	}
+	appendLine(t, sentinel, "refresh\n")
+
+	select {
+	case <-changed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a change signal after the hook sentinel was written to")
+	}
+}
+
+// appendLine appends content to path, creating it (and its parent directory)
+// if needed.
+func appendLine(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("failed to create parent dir for %s: %v", path, err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", path, err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(content); err != nil {
+		t.Fatalf("failed to append to %s: %v", path, err)
+	}
+}
@@ -0,0 +1,221 @@
+// Package tail streams a growing log file's new lines in real time,
+// following it across rotation by rename to an archive (how claude-tmux's
+// own event shards get rotated) or by removal and re-creation, so a
+// long-running consumer (a dashboard, `claude-tmux logs -f`) never has to
+// restart to keep up. A file truncated in place is not detected.
+package tail
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// LogLine is a single line delivered by Tail, in the order it was read.
+// Dropped counts how many lines were discarded since the last delivered
+// line because the channel was full — non-zero here means a slow consumer
+// fell behind, not that anything is wrong with the file itself.
+type LogLine struct {
+	Path    string
+	Text    string
+	Dropped int64
+}
+
+// Options controls Tail's behavior.
+type Options struct {
+	// ChannelSize bounds the returned channel's buffer. Once full, new
+	// lines are dropped rather than blocking the watcher.
+	ChannelSize int
+	// PollInterval is a fallback poll period for filesystems (NFS, some
+	// containers) where fsnotify events are unreliable or absent. Zero
+	// disables polling and relies on fsnotify alone.
+	PollInterval time.Duration
+}
+
+// DefaultOptions is what Tail uses: a modestly sized channel plus a 2s
+// fallback poll so NFS-style mounts still make progress.
+func DefaultOptions() Options {
+	return Options{ChannelSize: 256, PollInterval: 2 * time.Second}
+}
+
+// Tail streams path's lines as they're appended. It is Tail with
+// DefaultOptions(); see TailWithOptions.
+func Tail(ctx context.Context, path string) (<-chan LogLine, error) {
+	return TailWithOptions(ctx, path, DefaultOptions())
+}
+
+// TailWithOptions streams path's lines as they're appended, following it
+// across rotation: a Rename or Remove event (how archive-based rotation or
+// a delete-and-recreate manifests) means the file we had open is gone,
+// so we close our handle and wait; a subsequent Create event for path
+// means a fresh file has taken its place, so we reopen and resume from
+// offset 0 — no lines are dropped across the boundary. The returned
+// channel is closed when ctx is canceled or the watch can no longer
+// continue.
+func TailWithOptions(ctx context.Context, path string, opts Options) (<-chan LogLine, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start watching %q: %w", path, err)
+	}
+
+	dir := filepath.Dir(path)
+	if err := watcher.Add(dir); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("failed to watch directory %q: %w", dir, err)
+	}
+	// Also watch the file directly where the platform's backend supports
+	// it; the directory watch alone is enough to catch rotation, but a
+	// direct watch gets us Write events without waiting on the poll
+	// fallback on backends that deliver them differently per-path.
+	_ = watcher.Add(path)
+
+	t := &tailer{path: path, watcher: watcher, lines: make(chan LogLine, opts.ChannelSize)}
+	t.openAtEnd()
+
+	go t.run(ctx, opts.PollInterval)
+
+	return t.lines, nil
+}
+
+// tailer holds one Tail call's open-file state and drives its event loop.
+type tailer struct {
+	path    string
+	watcher *fsnotify.Watcher
+	lines   chan LogLine
+	dropped int64
+
+	file   *os.File
+	reader *bufio.Reader
+}
+
+func (t *tailer) run(ctx context.Context, pollInterval time.Duration) {
+	defer t.watcher.Close()
+	defer t.closeFile()
+	defer close(t.lines)
+
+	var tick <-chan time.Time
+	if pollInterval > 0 {
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-t.watcher.Events:
+			if !ok {
+				return
+			}
+			t.handleEvent(event)
+		case _, ok := <-t.watcher.Errors:
+			if !ok {
+				return
+			}
+			// A watcher error doesn't mean the file is gone; keep following
+			// on a best-effort basis and let the poll fallback cover us.
+		case <-tick:
+			t.drain()
+		}
+	}
+}
+
+func (t *tailer) handleEvent(event fsnotify.Event) {
+	if event.Name != t.path {
+		return // an unrelated entry in the same watched directory
+	}
+	switch {
+	case event.Op&(fsnotify.Rename|fsnotify.Remove) != 0:
+		t.closeFile()
+	case event.Op&fsnotify.Create != 0:
+		t.reopenFromStart()
+	case event.Op&(fsnotify.Write|fsnotify.Chmod) != 0:
+		t.drain()
+	}
+}
+
+// openAtEnd opens path for the first time, seeking to its current end so
+// only lines appended after Tail was called are streamed.
+func (t *tailer) openAtEnd() {
+	file, err := os.Open(t.path)
+	if err != nil {
+		return
+	}
+	if _, err := file.Seek(0, io.SeekEnd); err != nil {
+		file.Close()
+		return
+	}
+	t.file = file
+	t.reader = bufio.NewReader(file)
+}
+
+// reopenFromStart opens path fresh after a rotation recreated it, resuming
+// from offset 0 since a just-rotated file starts out empty or new.
+func (t *tailer) reopenFromStart() {
+	t.closeFile()
+	file, err := os.Open(t.path)
+	if err != nil {
+		return
+	}
+	t.file = file
+	t.reader = bufio.NewReader(file)
+	t.drain()
+}
+
+func (t *tailer) closeFile() {
+	if t.file != nil {
+		t.file.Close()
+	}
+	t.file = nil
+	t.reader = nil
+}
+
+// drain reads every complete line available from the current offset,
+// leaving a trailing partial line (a writer caught mid-append) unconsumed
+// for the next drain. If no file is currently open — path hadn't been
+// created yet, or we're waiting on its Create event — it tries to open it.
+func (t *tailer) drain() {
+	if t.file == nil {
+		t.reopenFromStart()
+		return
+	}
+	for {
+		line, err := t.reader.ReadString('\n')
+		if strings.HasSuffix(line, "\n") {
+			t.emit(strings.TrimSuffix(line, "\n"))
+		}
+		if err != nil {
+			// At EOF the reader's buffer is empty, so the file offset sits
+			// just past the partial line; step back over it so it's re-read
+			// whole once its newline arrives.
+			if err == io.EOF && line != "" {
+				if _, serr := t.file.Seek(-int64(len(line)), io.SeekCurrent); serr == nil {
+					t.reader.Reset(t.file)
+				}
+			}
+			return
+		}
+	}
+}
+
+// emit delivers text on the bounded channel, or — if the consumer is too
+// slow to keep up and the channel is full — drops it and counts the drop
+// to be reported alongside the next line that does get through.
+func (t *tailer) emit(text string) {
+	dropped := atomic.SwapInt64(&t.dropped, 0)
+	line := LogLine{Path: t.path, Text: text, Dropped: dropped}
+	select {
+	case t.lines <- line:
+	default:
+		atomic.AddInt64(&t.dropped, dropped+1)
+	}
+}
@@ -0,0 +1,111 @@
+package tail
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// collect reads up to n LogLines from ch, failing the test if they don't
+// arrive within the timeout.
+func collect(t *testing.T, ch <-chan LogLine, n int, timeout time.Duration) []LogLine {
+	t.Helper()
+	var lines []LogLine
+	deadline := time.After(timeout)
+	for len(lines) < n {
+		select {
+		case line, ok := <-ch:
+			if !ok {
+				t.Fatalf("channel closed early with %d of %d lines", len(lines), n)
+			}
+			lines = append(lines, line)
+		case <-deadline:
+			t.Fatalf("timed out waiting for %d lines, got %d: %+v", n, len(lines), lines)
+		}
+	}
+	return lines
+}
+
+func TestTail_StreamsAppendedLines(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "events.log")
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		t.Fatalf("failed to create log: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, err := Tail(ctx, path)
+	if err != nil {
+		t.Fatalf("Tail error: %v", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("failed to open for append: %v", err)
+	}
+	file.WriteString("first\nsecond\n")
+	file.Close()
+
+	lines := collect(t, ch, 2, 5*time.Second)
+	if lines[0].Text != "first" || lines[1].Text != "second" {
+		t.Fatalf("expected first/second in order, got %+v", lines)
+	}
+}
+
+func TestTail_ResumesAfterRenameRotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "events.log")
+	if err := os.WriteFile(path, []byte("before rotation\n"), 0644); err != nil {
+		t.Fatalf("failed to create log: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, err := Tail(ctx, path)
+	if err != nil {
+		t.Fatalf("Tail error: %v", err)
+	}
+
+	// Rotate: rename the old file out of the way and start a fresh one at
+	// the same path, the same way rotateShard does.
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatalf("failed to rotate: %v", err)
+	}
+	if err := os.WriteFile(path, []byte("after rotation\n"), 0644); err != nil {
+		t.Fatalf("failed to recreate log: %v", err)
+	}
+
+	lines := collect(t, ch, 1, 5*time.Second)
+	if lines[0].Text != "after rotation" {
+		t.Fatalf("expected the post-rotation line, got %+v", lines)
+	}
+}
+
+func TestTail_ClosesChannelOnContextCancel(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "events.log")
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		t.Fatalf("failed to create log: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := Tail(ctx, path)
+	if err != nil {
+		t.Fatalf("Tail error: %v", err)
+	}
+	cancel()
+
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Fatalf("expected the channel to close, got a line instead")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for the channel to close")
+	}
+}
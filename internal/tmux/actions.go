@@ -0,0 +1,95 @@
+package tmux
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// KillPane kills the pane at target ("session:window.pane").
+func KillPane(target string) error {
+	cmd := exec.Command("tmux", "kill-pane", "-t", target)
+	if err := ActiveCommander.ExecSilently(cmd); err != nil {
+		return fmt.Errorf("tmux kill-pane failed: %w", err)
+	}
+	return nil
+}
+
+// KillWindow kills the window at target ("session:window").
+func KillWindow(target string) error {
+	cmd := exec.Command("tmux", "kill-window", "-t", target)
+	if err := ActiveCommander.ExecSilently(cmd); err != nil {
+		return fmt.Errorf("tmux kill-window failed: %w", err)
+	}
+	return nil
+}
+
+// SendKeys sends keys to target's pane, interpreted exactly as tmux
+// send-keys would (e.g. "C-c" for Ctrl-C).
+func SendKeys(target, keys string) error {
+	cmd := exec.Command("tmux", "send-keys", "-t", target, keys)
+	if err := ActiveCommander.ExecSilently(cmd); err != nil {
+		return fmt.Errorf("tmux send-keys failed: %w", err)
+	}
+	return nil
+}
+
+// SubmitKeys sends keys to target's pane followed by Enter, for answering an
+// interactive prompt (e.g. a numbered permission menu) rather than sending a
+// raw control sequence the way SendKeys does.
+func SubmitKeys(target string, keys ...string) error {
+	args := append([]string{"send-keys", "-t", target}, keys...)
+	args = append(args, "Enter")
+	cmd := exec.Command("tmux", args...)
+	if err := ActiveCommander.ExecSilently(cmd); err != nil {
+		return fmt.Errorf("tmux send-keys failed: %w", err)
+	}
+	return nil
+}
+
+// RenameWindow renames target's window ("session:window") to name.
+func RenameWindow(target, name string) error {
+	cmd := exec.Command("tmux", "rename-window", "-t", target, name)
+	if err := ActiveCommander.ExecSilently(cmd); err != nil {
+		return fmt.Errorf("tmux rename-window failed: %w", err)
+	}
+	return nil
+}
+
+// DetachClient detaches whatever client is currently attached to target's
+// session.
+func DetachClient(target string) error {
+	cmd := exec.Command("tmux", "detach-client", "-t", target)
+	if err := ActiveCommander.ExecSilently(cmd); err != nil {
+		return fmt.Errorf("tmux detach-client failed: %w", err)
+	}
+	return nil
+}
+
+// clipboardCommands are candidate clipboard utilities, tried in order; the
+// first one found on PATH is used. pbcopy covers macOS, wl-copy covers
+// Wayland, and xclip/xsel cover X11.
+var clipboardCommands = [][]string{
+	{"pbcopy"},
+	{"wl-copy"},
+	{"xclip", "-selection", "clipboard"},
+	{"xsel", "--clipboard", "--input"},
+}
+
+// CopyToClipboard copies text to the system clipboard via whichever
+// clipboard utility is available.
+func CopyToClipboard(text string) error {
+	for _, argv := range clipboardCommands {
+		path, err := exec.LookPath(argv[0])
+		if err != nil {
+			continue
+		}
+		cmd := exec.Command(path, argv[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		if err := ActiveCommander.ExecSilently(cmd); err != nil {
+			return fmt.Errorf("%s failed: %w", argv[0], err)
+		}
+		return nil
+	}
+	return fmt.Errorf("no clipboard utility found (tried pbcopy, wl-copy, xclip, xsel)")
+}
@@ -0,0 +1,129 @@
+package tmux
+
+import (
+	"os"
+	"os/exec"
+	"reflect"
+	"testing"
+)
+
+type recordingCommander struct {
+	args  [][]string
+	stdin string
+	err   error
+}
+
+func (r *recordingCommander) Exec(cmd *exec.Cmd) (string, error) {
+	r.args = append(r.args, cmd.Args)
+	return "", r.err
+}
+
+func (r *recordingCommander) ExecSilently(cmd *exec.Cmd) error {
+	r.args = append(r.args, cmd.Args)
+	if cmd.Stdin != nil {
+		buf := make([]byte, 4096)
+		n, _ := cmd.Stdin.Read(buf)
+		r.stdin = string(buf[:n])
+	}
+	return r.err
+}
+
+func withRecordingCommander(t *testing.T) *recordingCommander {
+	t.Helper()
+	old := ActiveCommander
+	rec := &recordingCommander{}
+	ActiveCommander = rec
+	t.Cleanup(func() { ActiveCommander = old })
+	return rec
+}
+
+func TestKillPane(t *testing.T) {
+	rec := withRecordingCommander(t)
+
+	if err := KillPane("work:0.0"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"tmux", "kill-pane", "-t", "work:0.0"}
+	if !reflect.DeepEqual(rec.args[0], want) {
+		t.Errorf("expected args %v, got %v", want, rec.args[0])
+	}
+}
+
+func TestKillWindow(t *testing.T) {
+	rec := withRecordingCommander(t)
+
+	if err := KillWindow("work:0"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"tmux", "kill-window", "-t", "work:0"}
+	if !reflect.DeepEqual(rec.args[0], want) {
+		t.Errorf("expected args %v, got %v", want, rec.args[0])
+	}
+}
+
+func TestSendKeys(t *testing.T) {
+	rec := withRecordingCommander(t)
+
+	if err := SendKeys("work:0.0", "C-c"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"tmux", "send-keys", "-t", "work:0.0", "C-c"}
+	if !reflect.DeepEqual(rec.args[0], want) {
+		t.Errorf("expected args %v, got %v", want, rec.args[0])
+	}
+}
+
+func TestSubmitKeys(t *testing.T) {
+	rec := withRecordingCommander(t)
+
+	if err := SubmitKeys("work:0.0", "1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"tmux", "send-keys", "-t", "work:0.0", "1", "Enter"}
+	if !reflect.DeepEqual(rec.args[0], want) {
+		t.Errorf("expected args %v, got %v", want, rec.args[0])
+	}
+}
+
+func TestRenameWindow(t *testing.T) {
+	rec := withRecordingCommander(t)
+
+	if err := RenameWindow("work:0", "scratch"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"tmux", "rename-window", "-t", "work:0", "scratch"}
+	if !reflect.DeepEqual(rec.args[0], want) {
+		t.Errorf("expected args %v, got %v", want, rec.args[0])
+	}
+}
+
+func TestDetachClient(t *testing.T) {
+	rec := withRecordingCommander(t)
+
+	if err := DetachClient("work:0.0"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"tmux", "detach-client", "-t", "work:0.0"}
+	if !reflect.DeepEqual(rec.args[0], want) {
+		t.Errorf("expected args %v, got %v", want, rec.args[0])
+	}
+}
+
+func TestAction_PropagatesCommanderError(t *testing.T) {
+	rec := withRecordingCommander(t)
+	rec.err = exec.ErrNotFound
+
+	if err := KillPane("work:0.0"); err == nil {
+		t.Fatal("expected an error when the commander fails")
+	}
+}
+
+func TestCopyToClipboard_NoUtilityOnPath(t *testing.T) {
+	old := os.Getenv("PATH")
+	os.Setenv("PATH", "")
+	t.Cleanup(func() { os.Setenv("PATH", old) })
+
+	if err := CopyToClipboard("work:0.0"); err == nil {
+		t.Fatal("expected an error when no clipboard utility is on PATH")
+	}
+}
@@ -0,0 +1,19 @@
+package tmux
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+)
+
+// CapturePane returns the last lines of target's pane contents, with ANSI
+// color codes preserved (-e) so callers can render it styled rather than as
+// plain text.
+func CapturePane(target string, lines int) (string, error) {
+	cmd := exec.Command("tmux", "capture-pane", "-p", "-e", "-t", target, "-S", "-"+strconv.Itoa(lines))
+	out, err := ActiveCommander.Exec(cmd)
+	if err != nil {
+		return "", fmt.Errorf("tmux capture-pane failed: %w", err)
+	}
+	return out, nil
+}
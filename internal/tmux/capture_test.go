@@ -0,0 +1,28 @@
+package tmux
+
+import (
+	"os/exec"
+	"reflect"
+	"testing"
+)
+
+func TestCapturePane(t *testing.T) {
+	rec := withRecordingCommander(t)
+
+	if _, err := CapturePane("work:0.0", 50); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"tmux", "capture-pane", "-p", "-e", "-t", "work:0.0", "-S", "-50"}
+	if !reflect.DeepEqual(rec.args[0], want) {
+		t.Errorf("expected args %v, got %v", want, rec.args[0])
+	}
+}
+
+func TestCapturePane_PropagatesCommanderError(t *testing.T) {
+	rec := withRecordingCommander(t)
+	rec.err = exec.ErrNotFound
+
+	if _, err := CapturePane("work:0.0", 50); err == nil {
+		t.Fatal("expected an error when the commander fails")
+	}
+}
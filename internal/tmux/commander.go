@@ -0,0 +1,115 @@
+package tmux
+
+import (
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Commander abstracts running external commands so callers can substitute a
+// fake in tests and so debug mode can trace every invocation in one place.
+type Commander interface {
+	// Exec runs cmd and returns its captured stdout.
+	Exec(cmd *exec.Cmd) (string, error)
+	// ExecSilently runs cmd, discarding its output.
+	ExecSilently(cmd *exec.Cmd) error
+}
+
+// DefaultCommander runs commands for real, optionally logging every
+// invocation's argv (and any failure) to logger.
+type DefaultCommander struct {
+	logger *log.Logger
+}
+
+// NewDefaultCommander returns a DefaultCommander that logs to logger if
+// non-nil.
+func NewDefaultCommander(logger *log.Logger) *DefaultCommander {
+	return &DefaultCommander{logger: logger}
+}
+
+func (c *DefaultCommander) Exec(cmd *exec.Cmd) (string, error) {
+	c.logInvocation(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		c.logFailure(cmd, err)
+		return "", err
+	}
+	return string(out), nil
+}
+
+func (c *DefaultCommander) ExecSilently(cmd *exec.Cmd) error {
+	c.logInvocation(cmd)
+	if err := cmd.Run(); err != nil {
+		c.logFailure(cmd, err)
+		return err
+	}
+	return nil
+}
+
+func (c *DefaultCommander) logInvocation(cmd *exec.Cmd) {
+	if c.logger == nil {
+		return
+	}
+	c.logger.Printf("exec: %s", strings.Join(cmd.Args, " "))
+}
+
+func (c *DefaultCommander) logFailure(cmd *exec.Cmd, err error) {
+	if c.logger == nil {
+		return
+	}
+	c.logger.Printf("exec failed: %s: %v", strings.Join(cmd.Args, " "), err)
+}
+
+// DebugEnabled reports whether command tracing should be enabled, via a
+// "--debug" entry in args or CLAUDE_TMUX_DEBUG=1.
+func DebugEnabled(args []string) bool {
+	if os.Getenv("CLAUDE_TMUX_DEBUG") == "1" {
+		return true
+	}
+	for _, arg := range args {
+		if arg == "--debug" {
+			return true
+		}
+	}
+	return false
+}
+
+// debugLogPath returns the path debug logging should write to, rooted at
+// XDG_CONFIG_HOME (falling back to ~/.config).
+func debugLogPath() (string, error) {
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		configHome = filepath.Join(homeDir, ".config")
+	}
+	return filepath.Join(configHome, "claude-tmux", "debug.log"), nil
+}
+
+// NewDebugLogger opens (creating if necessary) the debug log file and
+// returns a logger writing to it, or nil if args/the environment don't
+// request debug mode.
+func NewDebugLogger(args []string) (*log.Logger, error) {
+	if !DebugEnabled(args) {
+		return nil, nil
+	}
+
+	path, err := debugLogPath()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return log.New(file, "", log.LstdFlags), nil
+}
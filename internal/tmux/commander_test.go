@@ -0,0 +1,54 @@
+package tmux
+
+import (
+	"bytes"
+	"log"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestDefaultCommander_Exec(t *testing.T) {
+	var buf bytes.Buffer
+	commander := NewDefaultCommander(log.New(&buf, "", 0))
+
+	out, err := commander.Exec(exec.Command("echo", "hello"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.TrimSpace(out) != "hello" {
+		t.Errorf("expected output %q, got %q", "hello", out)
+	}
+	if !strings.Contains(buf.String(), "exec: echo hello") {
+		t.Errorf("expected log to mention the command, got %q", buf.String())
+	}
+}
+
+func TestDefaultCommander_ExecFailureLogged(t *testing.T) {
+	var buf bytes.Buffer
+	commander := NewDefaultCommander(log.New(&buf, "", 0))
+
+	_, err := commander.Exec(exec.Command("false"))
+	if err == nil {
+		t.Fatal("expected an error from a failing command")
+	}
+	if !strings.Contains(buf.String(), "exec failed:") {
+		t.Errorf("expected failure to be logged, got %q", buf.String())
+	}
+}
+
+func TestDefaultCommander_NoLoggerIsSilent(t *testing.T) {
+	commander := NewDefaultCommander(nil)
+	if _, err := commander.Exec(exec.Command("echo", "ok")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestDebugEnabled(t *testing.T) {
+	if DebugEnabled(nil) {
+		t.Error("expected DebugEnabled(nil) to be false without CLAUDE_TMUX_DEBUG")
+	}
+	if !DebugEnabled([]string{"claude-tmux", "--debug"}) {
+		t.Error("expected DebugEnabled to be true when --debug is passed")
+	}
+}
@@ -0,0 +1,252 @@
+package tmux
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// controlSessionName is the dedicated tmux session Controller attaches to as
+// a control-mode client. Using new-session -A means it's created on first
+// use and reused afterwards, so a Controller never disturbs a session a
+// user is actually looking at.
+const controlSessionName = "claude-tmux-control"
+
+// Notification is a push update parsed from a tmux control-mode
+// notification line (%output, %window-*, %session-changed,
+// %pane-mode-changed, ...), delivered outside Command's request/response
+// flow.
+type Notification struct {
+	Name string   // e.g. "output", "window-renamed", "session-changed"
+	Args []string // the line's remaining whitespace-separated fields
+}
+
+// commandResult is the framed response to one Command call.
+type commandResult struct {
+	lines []string
+	err   error
+}
+
+// Controller is a long-lived `tmux -C` (control mode) client. Commands
+// submitted through Command are written to its stdin and answered over a
+// single %begin/%end-framed stdout stream, so driving it doesn't fork a new
+// tmux process per call the way ActiveCommander's one-shot Exec does.
+// Notifications tmux pushes outside that request/response flow (pane
+// output, window/session changes, ...) are delivered on Notifications.
+//
+// A Controller reconnects on its own if the control connection hits EOF
+// (the tmux server exited or was killed). If that reconnect attempt fails —
+// no tmux on PATH any more, say — Done closes and the Controller is no
+// longer usable; callers should fall back to one-shot exec calls.
+type Controller struct {
+	reconnect func() error // swapped out in tests to avoid spawning a real tmux on reconnect
+
+	mu      sync.Mutex
+	cmd     *exec.Cmd
+	stdin   io.WriteCloser
+	pending chan chan commandResult // FIFO queue of response channels, one per in-flight Command
+
+	notify   chan Notification
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+// NewController spawns `tmux -C new-session -A -s claude-tmux-control` and
+// starts reading its control-mode stream in the background. It returns an
+// error if tmux isn't on PATH or fails to start; callers should treat that
+// as "control mode unavailable" and keep using the one-shot exec path.
+func NewController() (*Controller, error) {
+	c := &Controller{
+		notify: make(chan Notification, 64),
+		done:   make(chan struct{}),
+	}
+	c.reconnect = c.connect
+	if err := c.connect(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// connect spawns the control client and hands its pipes to attachStream.
+func (c *Controller) connect() error {
+	tmuxBin, err := exec.LookPath("tmux")
+	if err != nil {
+		return fmt.Errorf("tmux not found: %w", err)
+	}
+
+	cmd := exec.Command(tmuxBin, "-C", "new-session", "-A", "-s", controlSessionName)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("open control client stdin: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("open control client stdout: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("start control client: %w", err)
+	}
+
+	c.mu.Lock()
+	c.cmd = cmd
+	c.mu.Unlock()
+	c.attachStream(stdin, stdout)
+	return nil
+}
+
+// attachStream wires up a freshly (re)connected control client's pipes and
+// starts reading its stdout. Split out from connect so tests can drive the
+// protocol over an io.Pipe without spawning a real tmux.
+func (c *Controller) attachStream(stdin io.WriteCloser, stdout io.Reader) {
+	c.mu.Lock()
+	c.stdin = stdin
+	c.pending = make(chan chan commandResult, 64)
+	c.mu.Unlock()
+	go c.readLoop(stdout)
+}
+
+// Command submits a tmux command line (e.g. "list-panes -a -F ...") and
+// blocks until its framed %begin/%end response arrives, returning the lines
+// in between. It returns an error if tmux answered with %error, or if the
+// connection closes before a response arrives.
+func (c *Controller) Command(cmdLine string) ([]string, error) {
+	result := make(chan commandResult, 1)
+
+	c.mu.Lock()
+	select {
+	case <-c.done:
+		c.mu.Unlock()
+		return nil, fmt.Errorf("control client is closed")
+	default:
+	}
+	if _, err := io.WriteString(c.stdin, cmdLine+"\n"); err != nil {
+		c.mu.Unlock()
+		return nil, fmt.Errorf("write command: %w", err)
+	}
+	c.pending <- result
+	c.mu.Unlock()
+
+	select {
+	case r := <-result:
+		return r.lines, r.err
+	case <-c.done:
+		return nil, fmt.Errorf("control client closed before %q returned", cmdLine)
+	}
+}
+
+// Notifications returns the channel Controller delivers push notifications
+// on. It's closed once the Controller gives up reconnecting and Done
+// closes.
+func (c *Controller) Notifications() <-chan Notification {
+	return c.notify
+}
+
+// Done returns a channel that's closed once the Controller is no longer
+// usable: Close was called, or a reconnect attempt after EOF failed.
+func (c *Controller) Done() <-chan struct{} {
+	return c.done
+}
+
+// Close terminates the control client.
+func (c *Controller) Close() error {
+	c.mu.Lock()
+	stdin := c.stdin
+	cmd := c.cmd
+	c.mu.Unlock()
+
+	var err error
+	if stdin != nil {
+		err = stdin.Close()
+	}
+	if cmd != nil {
+		_ = cmd.Wait()
+	}
+	c.closeDone()
+	return err
+}
+
+func (c *Controller) closeDone() {
+	c.doneOnce.Do(func() { close(c.done) })
+}
+
+// readLoop parses tmux's control-mode protocol off stdout: a %begin line
+// opens a block, an %end or %error line closes it and answers the oldest
+// pending Command in FIFO order (tmux always answers commands in the order
+// they were submitted); any other line starting with "%" outside a block is
+// a push notification.
+//
+// When stdout closes, the control client disconnected — most likely the
+// tmux server exited. readLoop fails every still-pending Command, then
+// tries to reconnect; if that fails too, the Controller is done for good.
+func (c *Controller) readLoop(stdout io.Reader) {
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var block []string
+	inBlock := false
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "%begin"):
+			inBlock = true
+			block = nil
+		case strings.HasPrefix(line, "%end"), strings.HasPrefix(line, "%error"):
+			result := commandResult{lines: block}
+			if strings.HasPrefix(line, "%error") {
+				result.err = fmt.Errorf("tmux: %s", strings.Join(block, "; "))
+			}
+			inBlock = false
+			block = nil
+			c.resolveNext(result)
+		case inBlock:
+			block = append(block, line)
+		case strings.HasPrefix(line, "%"):
+			c.notify <- parseNotification(line)
+		}
+	}
+
+	c.drainPending(fmt.Errorf("control client disconnected"))
+	if err := c.reconnect(); err != nil {
+		close(c.notify)
+		c.closeDone()
+	}
+}
+
+// resolveNext hands result to the oldest Command still waiting on a
+// response. If nothing is waiting (it shouldn't happen, since tmux answers
+// commands in submission order) the result is dropped rather than blocking
+// the read loop.
+func (c *Controller) resolveNext(result commandResult) {
+	select {
+	case resultCh := <-c.pending:
+		resultCh <- result
+	default:
+	}
+}
+
+// drainPending fails every Command still waiting on this connection's
+// pending queue, so callers don't block forever after a disconnect.
+func (c *Controller) drainPending(err error) {
+	for {
+		select {
+		case resultCh := <-c.pending:
+			resultCh <- commandResult{err: err}
+		default:
+			return
+		}
+	}
+}
+
+// parseNotification splits a "%name arg1 arg2 ..." control-mode
+// notification line into a Notification.
+func parseNotification(line string) Notification {
+	fields := strings.Fields(strings.TrimPrefix(line, "%"))
+	if len(fields) == 0 {
+		return Notification{}
+	}
+	return Notification{Name: fields[0], Args: fields[1:]}
+}
@@ -0,0 +1,240 @@
+package tmux
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+// newTestController wires a Controller to an in-memory pipe pair standing
+// in for tmux's control-mode stdin/stdout, with reconnecting disabled, so
+// tests can drive the protocol without spawning a real tmux.
+func newTestController(t *testing.T) (*Controller, <-chan string) {
+	t.Helper()
+
+	stdinR, stdinW := io.Pipe()
+	stdoutR, stdoutW := io.Pipe()
+
+	commands := make(chan string, 16)
+	go func() {
+		scanner := bufio.NewScanner(stdinR)
+		for scanner.Scan() {
+			commands <- scanner.Text()
+		}
+		close(commands)
+	}()
+
+	c := &Controller{
+		notify: make(chan Notification, 64),
+		done:   make(chan struct{}),
+	}
+	c.reconnect = func() error { return errors.New("reconnect disabled in test") }
+	c.attachStream(stdinW, stdoutR)
+
+	t.Cleanup(func() {
+		_ = stdinW.Close()
+		_ = stdoutW.Close()
+	})
+
+	return c, commands
+}
+
+func TestController_WritesCommandLineToStdin(t *testing.T) {
+	c, commands := newTestController(t)
+
+	go func() { _, _ = c.Command("list-panes -a") }()
+
+	select {
+	case cmdLine := <-commands:
+		if cmdLine != "list-panes -a" {
+			t.Errorf("expected %q, got %q", "list-panes -a", cmdLine)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the command to be written")
+	}
+}
+
+func TestController_CommandRoundTrip(t *testing.T) {
+	stdinR, stdinW := io.Pipe()
+	stdoutR, stdoutW := io.Pipe()
+
+	commands := make(chan string, 16)
+	go func() {
+		scanner := bufio.NewScanner(stdinR)
+		for scanner.Scan() {
+			commands <- scanner.Text()
+		}
+	}()
+
+	c := &Controller{
+		notify: make(chan Notification, 64),
+		done:   make(chan struct{}),
+	}
+	c.reconnect = func() error { return errors.New("reconnect disabled in test") }
+	c.attachStream(stdinW, stdoutR)
+	t.Cleanup(func() { _ = stdinW.Close(); _ = stdoutW.Close() })
+
+	go func() {
+		cmdLine := <-commands
+		if cmdLine != "list-panes -a" {
+			t.Errorf("expected %q, got %q", "list-panes -a", cmdLine)
+			return
+		}
+		io.WriteString(stdoutW, "%begin 1 1 1\n")
+		io.WriteString(stdoutW, "12345 work 0 0\n")
+		io.WriteString(stdoutW, "%end 1 1 1\n")
+	}()
+
+	lines, err := c.Command("list-panes -a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"12345 work 0 0"}
+	if len(lines) != 1 || lines[0] != want[0] {
+		t.Errorf("expected %v, got %v", want, lines)
+	}
+}
+
+func TestController_CommandPropagatesTmuxError(t *testing.T) {
+	stdinR, stdinW := io.Pipe()
+	stdoutR, stdoutW := io.Pipe()
+
+	go func() {
+		scanner := bufio.NewScanner(stdinR)
+		for scanner.Scan() {
+		}
+	}()
+
+	c := &Controller{
+		notify: make(chan Notification, 64),
+		done:   make(chan struct{}),
+	}
+	c.reconnect = func() error { return errors.New("reconnect disabled in test") }
+	c.attachStream(stdinW, stdoutR)
+	t.Cleanup(func() { _ = stdinW.Close(); _ = stdoutW.Close() })
+
+	go func() {
+		io.WriteString(stdoutW, "%begin 1 1 1\n")
+		io.WriteString(stdoutW, "no such pane\n")
+		io.WriteString(stdoutW, "%error 1 1 1\n")
+	}()
+
+	_, err := c.Command("capture-pane -t bogus")
+	if err == nil {
+		t.Fatal("expected an error for an error response")
+	}
+}
+
+func TestController_NotificationsDeliveredOutsideCommandBlocks(t *testing.T) {
+	stdinR, stdinW := io.Pipe()
+	stdoutR, stdoutW := io.Pipe()
+
+	go func() {
+		scanner := bufio.NewScanner(stdinR)
+		for scanner.Scan() {
+		}
+	}()
+
+	c := &Controller{
+		notify: make(chan Notification, 64),
+		done:   make(chan struct{}),
+	}
+	c.reconnect = func() error { return errors.New("reconnect disabled in test") }
+	c.attachStream(stdinW, stdoutR)
+	t.Cleanup(func() { _ = stdinW.Close(); _ = stdoutW.Close() })
+
+	go func() {
+		io.WriteString(stdoutW, "%window-renamed @1 newname\n")
+	}()
+
+	select {
+	case n := <-c.Notifications():
+		if n.Name != "window-renamed" {
+			t.Errorf("expected name %q, got %q", "window-renamed", n.Name)
+		}
+		wantArgs := []string{"@1", "newname"}
+		if len(n.Args) != len(wantArgs) || n.Args[0] != wantArgs[0] || n.Args[1] != wantArgs[1] {
+			t.Errorf("expected args %v, got %v", wantArgs, n.Args)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for notification")
+	}
+}
+
+func TestController_DisconnectFailsPendingCommandsAndClosesDone(t *testing.T) {
+	stdinR, stdinW := io.Pipe()
+	stdoutR, stdoutW := io.Pipe()
+
+	go func() {
+		scanner := bufio.NewScanner(stdinR)
+		for scanner.Scan() {
+		}
+	}()
+
+	c := &Controller{
+		notify: make(chan Notification, 64),
+		done:   make(chan struct{}),
+	}
+	c.reconnect = func() error { return errors.New("reconnect disabled in test") }
+	c.attachStream(stdinW, stdoutR)
+	t.Cleanup(func() { _ = stdinW.Close() })
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := c.Command("list-panes -a")
+		errCh <- err
+	}()
+
+	// Give Command a moment to register itself on the pending queue before
+	// we simulate the tmux server vanishing out from under it.
+	time.Sleep(10 * time.Millisecond)
+	_ = stdoutW.Close()
+
+	select {
+	case err := <-errCh:
+		if err == nil {
+			t.Fatal("expected the in-flight command to fail after disconnect")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for in-flight command to fail")
+	}
+
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for Done to close after a failed reconnect")
+	}
+}
+
+func TestController_CloseClosesDone(t *testing.T) {
+	c, _ := newTestController(t)
+
+	if err := c.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	select {
+	case <-c.Done():
+	default:
+		t.Fatal("expected Done to be closed after Close")
+	}
+}
+
+func TestParseNotification(t *testing.T) {
+	n := parseNotification("%session-changed $1 work")
+	if n.Name != "session-changed" {
+		t.Errorf("expected name %q, got %q", "session-changed", n.Name)
+	}
+	if len(n.Args) != 2 || n.Args[0] != "$1" || n.Args[1] != "work" {
+		t.Errorf("expected args [$1 work], got %v", n.Args)
+	}
+}
+
+func TestParseNotification_EmptyLine(t *testing.T) {
+	n := parseNotification("%")
+	if n.Name != "" || n.Args != nil {
+		t.Errorf("expected a zero-value Notification, got %+v", n)
+	}
+}
@@ -0,0 +1,39 @@
+package tmux
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// refreshHookNames are the tmux hooks InstallRefreshHooks wires up. Together
+// they cover the session/window lifecycle events the TUI's status display
+// cares about.
+var refreshHookNames = []string{
+	"pane-focus-in",
+	"session-created",
+	"session-closed",
+	"window-linked",
+}
+
+// InstallRefreshHooks registers global tmux hooks that append a line to
+// sentinelPath whenever a pane gains focus or a session/window is created or
+// closed. session.WatchForChanges tails sentinelPath the same way it tails
+// event shards, so a hook firing turns into a push notification instead of
+// the TUI having to poll for it.
+func InstallRefreshHooks(sentinelPath string) error {
+	shellCmd := fmt.Sprintf("echo refresh >> %s", shellQuote(sentinelPath))
+	for _, hook := range refreshHookNames {
+		cmd := exec.Command("tmux", "set-hook", "-g", hook, "run-shell "+shellQuote(shellCmd))
+		if err := ActiveCommander.ExecSilently(cmd); err != nil {
+			return fmt.Errorf("tmux set-hook %s failed: %w", hook, err)
+		}
+	}
+	return nil
+}
+
+// shellQuote wraps s as a single single-quoted shell word, escaping any
+// single quotes it contains.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
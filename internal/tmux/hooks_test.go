@@ -0,0 +1,49 @@
+package tmux
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestInstallRefreshHooks(t *testing.T) {
+	rec := withRecordingCommander(t)
+
+	if err := InstallRefreshHooks("/home/user/.claude-tmux/hooks.sentinel"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(rec.args) != len(refreshHookNames) {
+		t.Fatalf("expected %d set-hook calls, got %d", len(refreshHookNames), len(rec.args))
+	}
+	for i, hook := range refreshHookNames {
+		args := rec.args[i]
+		if args[0] != "tmux" || args[1] != "set-hook" || args[2] != "-g" || args[3] != hook {
+			t.Errorf("call %d: expected tmux set-hook -g %s ..., got %v", i, hook, args)
+		}
+		if !strings.Contains(args[4], "hooks.sentinel") {
+			t.Errorf("call %d: expected run-shell command to reference the sentinel path, got %q", i, args[4])
+		}
+	}
+}
+
+func TestInstallRefreshHooks_PropagatesCommanderError(t *testing.T) {
+	rec := withRecordingCommander(t)
+	rec.err = exec.ErrNotFound
+
+	if err := InstallRefreshHooks("/home/user/.claude-tmux/hooks.sentinel"); err == nil {
+		t.Fatal("expected an error when the commander fails")
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"/home/user/.claude-tmux/hooks.sentinel", "'/home/user/.claude-tmux/hooks.sentinel'"},
+		{"it's a path", `'it'\''s a path'`},
+	}
+	for _, tt := range tests {
+		if got := shellQuote(tt.in); got != tt.want {
+			t.Errorf("shellQuote(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
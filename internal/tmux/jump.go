@@ -7,6 +7,11 @@ import (
 	"syscall"
 )
 
+// ActiveCommander is the Commander used for tmux invocations that don't need
+// to replace the current process. Tests can substitute a fake to assert the
+// exact tmux command strings issued.
+var ActiveCommander Commander = NewDefaultCommander(nil)
+
 // Jump switches to the given tmux pane target (e.g. "session:window.pane").
 // It uses syscall.Exec to replace the current process so that the tmux popup
 // closes cleanly after the switch.
@@ -16,11 +21,25 @@ func Jump(target string) error {
 		return fmt.Errorf("tmux not found: %w", err)
 	}
 
+	var args []string
 	if insideTmux() {
-		return syscall.Exec(tmuxBin, []string{"tmux", "switch-client", "-t", target}, os.Environ())
+		args = []string{"tmux", "switch-client", "-t", target}
+	} else {
+		args = []string{"tmux", "attach-session", "-t", target}
 	}
 
-	return syscall.Exec(tmuxBin, []string{"tmux", "attach-session", "-t", target}, os.Environ())
+	logJumpArgs(args)
+	return syscall.Exec(tmuxBin, args, os.Environ())
+}
+
+// logJumpArgs traces a Jump invocation through ActiveCommander's logger, if
+// debug logging is enabled. Jump can't route the call itself through
+// Commander.Exec/ExecSilently since syscall.Exec replaces the process
+// before either could ever return a result.
+func logJumpArgs(args []string) {
+	if dc, ok := ActiveCommander.(*DefaultCommander); ok {
+		dc.logInvocation(&exec.Cmd{Args: args})
+	}
 }
 
 // insideTmux returns true if the current process is running inside a tmux session.
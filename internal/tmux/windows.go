@@ -0,0 +1,68 @@
+package tmux
+
+import (
+	"bufio"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// Window describes a single tmux window within a session.
+type Window struct {
+	Index  int
+	Name   string
+	Active bool
+}
+
+// ListWindows returns the windows belonging to target (a session name or
+// "session:window" target), in the order tmux reports them. tmux reuses and
+// gaps window indexes as windows are created and killed, so callers must not
+// assume index 0 exists or that indexes are contiguous.
+func ListWindows(target string) ([]Window, error) {
+	cmd := exec.Command("tmux", "list-windows", "-t", target,
+		"-F", "#{window_index} #{window_name} #{window_active}")
+	output, err := ActiveCommander.Exec(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("tmux list-windows failed: %w", err)
+	}
+
+	var windows []Window
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 {
+			continue
+		}
+
+		index, err := strconv.Atoi(fields[0])
+		if err != nil {
+			continue
+		}
+
+		windows = append(windows, Window{
+			Index:  index,
+			Name:   strings.Join(fields[1:len(fields)-1], " "),
+			Active: fields[len(fields)-1] == "1",
+		})
+	}
+
+	return windows, nil
+}
+
+// FirstWindowIndex returns the lowest window index among windows, or
+// fallback if windows is empty. tmux windows renumber and gap after kills
+// and moves, so the first window is not reliably index 0.
+func FirstWindowIndex(windows []Window, fallback int) int {
+	if len(windows) == 0 {
+		return fallback
+	}
+
+	first := windows[0].Index
+	for _, w := range windows[1:] {
+		if w.Index < first {
+			first = w.Index
+		}
+	}
+	return first
+}
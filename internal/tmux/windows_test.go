@@ -0,0 +1,68 @@
+package tmux
+
+import (
+	"os/exec"
+	"testing"
+)
+
+type fakeWindowsCommander struct {
+	output string
+}
+
+func (f *fakeWindowsCommander) Exec(cmd *exec.Cmd) (string, error) {
+	return f.output, nil
+}
+
+func (f *fakeWindowsCommander) ExecSilently(cmd *exec.Cmd) error {
+	return nil
+}
+
+func TestListWindows_ParsesGappedIndexes(t *testing.T) {
+	old := ActiveCommander
+	ActiveCommander = &fakeWindowsCommander{output: "2 one 0\n5 two 1\n7 three 0\n"}
+	defer func() { ActiveCommander = old }()
+
+	windows, err := ListWindows("work")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []Window{
+		{Index: 2, Name: "one", Active: false},
+		{Index: 5, Name: "two", Active: true},
+		{Index: 7, Name: "three", Active: false},
+	}
+	if len(windows) != len(want) {
+		t.Fatalf("expected %d windows, got %d", len(want), len(windows))
+	}
+	for i, w := range want {
+		if windows[i] != w {
+			t.Errorf("window %d: expected %+v, got %+v", i, w, windows[i])
+		}
+	}
+}
+
+func TestListWindows_NameWithSpaces(t *testing.T) {
+	old := ActiveCommander
+	ActiveCommander = &fakeWindowsCommander{output: "0 my window 1\n"}
+	defer func() { ActiveCommander = old }()
+
+	windows, err := ListWindows("work")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(windows) != 1 || windows[0].Name != "my window" {
+		t.Errorf("expected window named %q, got %+v", "my window", windows)
+	}
+}
+
+func TestFirstWindowIndex(t *testing.T) {
+	windows := []Window{{Index: 5}, {Index: 2}, {Index: 7}}
+	if got := FirstWindowIndex(windows, 0); got != 2 {
+		t.Errorf("expected 2, got %d", got)
+	}
+
+	if got := FirstWindowIndex(nil, 3); got != 3 {
+		t.Errorf("expected fallback 3, got %d", got)
+	}
+}
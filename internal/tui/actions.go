@@ -0,0 +1,150 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Jevs21/claude-tmux/internal/session"
+	"github.com/Jevs21/claude-tmux/internal/tmux"
+)
+
+// actionKind identifies which tmux operation a tuiAction performs.
+type actionKind int
+
+const (
+	actionAttach actionKind = iota
+	actionKillPane
+	actionKillWindow
+	actionSendCtrlC
+	actionRename
+	actionCopyTarget
+	actionDetach
+)
+
+// tuiAction is one entry in the action overlay triggered by "a" in
+// updateNormal. destructive actions route through modeConfirm before
+// running.
+type tuiAction struct {
+	label       string
+	destructive bool
+	kind        actionKind
+}
+
+// actionsForSession returns the actions available for s, in menu order.
+// Every action beyond Attach needs a live tmux target, so a detached session
+// offers none.
+func actionsForSession(s session.Session) []tuiAction {
+	if !s.Jumpable() {
+		return nil
+	}
+
+	return []tuiAction{
+		{label: "Attach", kind: actionAttach},
+		{label: "Kill pane", kind: actionKillPane, destructive: true},
+		{label: "Kill window", kind: actionKillWindow, destructive: true},
+		{label: "Send Ctrl-C", kind: actionSendCtrlC},
+		{label: "Rename window", kind: actionRename},
+		{label: "Copy tmux target", kind: actionCopyTarget},
+		{label: "Detach", kind: actionDetach, destructive: true},
+	}
+}
+
+// windowTarget returns s's "session:window" target, for actions that operate
+// on the whole window rather than s's specific pane.
+func windowTarget(s session.Session) string {
+	return fmt.Sprintf("%s:%d", s.TmuxSession, s.WindowIndex)
+}
+
+// executeAction runs action's tmux operation against s. actionAttach and
+// actionRename are handled specially by runAction and never reach here.
+func executeAction(kind actionKind, s session.Session) error {
+	switch kind {
+	case actionKillPane:
+		return tmux.KillPane(s.TmuxTarget)
+	case actionKillWindow:
+		return tmux.KillWindow(windowTarget(s))
+	case actionSendCtrlC:
+		return tmux.SendKeys(s.TmuxTarget, "C-c")
+	case actionCopyTarget:
+		return tmux.CopyToClipboard(s.TmuxTarget)
+	case actionDetach:
+		return tmux.DetachClient(s.TmuxTarget)
+	}
+	return nil
+}
+
+// bulkActionKind identifies which operation a tuiBulkAction runs against an
+// entire selection.
+type bulkActionKind int
+
+const (
+	bulkJumpThrough bulkActionKind = iota
+	bulkKillAll
+	bulkSendKeys
+	bulkExportJSON
+)
+
+// tuiBulkAction is one entry in the bulk action overlay triggered by "a" in
+// updateNormal when a selection is active.
+type tuiBulkAction struct {
+	label       string
+	destructive bool
+	kind        bulkActionKind
+}
+
+// bulkActionsForSelection returns the actions available against the current
+// selection, in menu order.
+func bulkActionsForSelection() []tuiBulkAction {
+	return []tuiBulkAction{
+		{label: "Jump through", kind: bulkJumpThrough},
+		{label: "Kill all", kind: bulkKillAll, destructive: true},
+		{label: "Send keys to all", kind: bulkSendKeys},
+		{label: "Export as JSON", kind: bulkExportJSON},
+	}
+}
+
+// executeBulkAction runs action's operation against every session in
+// targets. bulkSendKeys is handled specially by runBulkAction (it needs the
+// keys text first) and never reaches here.
+func executeBulkAction(kind bulkActionKind, targets []session.Session) error {
+	switch kind {
+	case bulkJumpThrough:
+		return bulkEach(targets, session.SwitchClient)
+	case bulkKillAll:
+		return bulkEach(targets, func(s session.Session) error { return tmux.KillPane(s.TmuxTarget) })
+	case bulkExportJSON:
+		return exportSelectionAsJSON(targets)
+	}
+	return nil
+}
+
+// sendKeysToAll sends keys to every session in targets, exactly as
+// SendKeys/tmux send-keys would.
+func sendKeysToAll(targets []session.Session, keys string) error {
+	return bulkEach(targets, func(s session.Session) error { return tmux.SendKeys(s.TmuxTarget, keys) })
+}
+
+// bulkEach runs fn against every session in targets, continuing past
+// failures so one bad target can't stop the rest of the selection from
+// being processed, and reports the first error encountered (prefixed with
+// the target it came from).
+func bulkEach(targets []session.Session, fn func(session.Session) error) error {
+	var firstErr error
+	for _, s := range targets {
+		if err := fn(s); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("%s: %w", s.DisplayTarget(), err)
+		}
+	}
+	return firstErr
+}
+
+// exportSelectionAsJSON copies targets to the clipboard as indented JSON,
+// mirroring actionCopyTarget's use of tmux.CopyToClipboard for a single
+// target.
+func exportSelectionAsJSON(targets []session.Session) error {
+	data, err := json.MarshalIndent(targets, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal selection: %w", err)
+	}
+	return tmux.CopyToClipboard(string(data))
+}
@@ -0,0 +1,94 @@
+package tui
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Jevs21/claude-tmux/internal/session"
+)
+
+func TestActionsForSession_DetachedSessionHasNoActions(t *testing.T) {
+	s := session.Session{SessionID: "s1"}
+	if actions := actionsForSession(s); actions != nil {
+		t.Fatalf("expected a detached session to offer no actions, got %+v", actions)
+	}
+}
+
+func TestActionsForSession_JumpableSessionListsExpectedActions(t *testing.T) {
+	s := session.Session{SessionID: "s1", TmuxTarget: "work:0.0", TmuxSession: "work", WindowIndex: 0}
+	actions := actionsForSession(s)
+
+	wantLabels := []string{"Attach", "Kill pane", "Kill window", "Send Ctrl-C", "Rename window", "Copy tmux target", "Detach"}
+	if len(actions) != len(wantLabels) {
+		t.Fatalf("expected %d actions, got %d: %+v", len(wantLabels), len(actions), actions)
+	}
+	for i, want := range wantLabels {
+		if actions[i].label != want {
+			t.Errorf("action %d: expected label %q, got %q", i, want, actions[i].label)
+		}
+	}
+}
+
+func TestActionsForSession_DestructiveActionsAreFlagged(t *testing.T) {
+	s := session.Session{SessionID: "s1", TmuxTarget: "work:0.0", TmuxSession: "work", WindowIndex: 0}
+	for _, action := range actionsForSession(s) {
+		wantDestructive := action.kind == actionKillPane || action.kind == actionKillWindow || action.kind == actionDetach
+		if action.destructive != wantDestructive {
+			t.Errorf("action %q: expected destructive=%v, got %v", action.label, wantDestructive, action.destructive)
+		}
+	}
+}
+
+func TestWindowTarget(t *testing.T) {
+	s := session.Session{TmuxSession: "work", WindowIndex: 3}
+	if got := windowTarget(s); got != "work:3" {
+		t.Errorf("expected \"work:3\", got %q", got)
+	}
+}
+
+func TestBulkActionsForSelection_ListsExpectedActions(t *testing.T) {
+	actions := bulkActionsForSelection()
+
+	wantLabels := []string{"Jump through", "Kill all", "Send keys to all", "Export as JSON"}
+	if len(actions) != len(wantLabels) {
+		t.Fatalf("expected %d actions, got %d: %+v", len(wantLabels), len(actions), actions)
+	}
+	for i, want := range wantLabels {
+		if actions[i].label != want {
+			t.Errorf("action %d: expected label %q, got %q", i, want, actions[i].label)
+		}
+	}
+}
+
+func TestBulkActionsForSelection_OnlyKillAllIsDestructive(t *testing.T) {
+	for _, action := range bulkActionsForSelection() {
+		wantDestructive := action.kind == bulkKillAll
+		if action.destructive != wantDestructive {
+			t.Errorf("action %q: expected destructive=%v, got %v", action.label, wantDestructive, action.destructive)
+		}
+	}
+}
+
+func TestBulkEach_ContinuesPastFailuresAndReturnsFirstError(t *testing.T) {
+	targets := []session.Session{
+		{SessionID: "s1", TmuxTarget: "work:0.0"},
+		{SessionID: "s2", TmuxTarget: "work:1.0"},
+		{SessionID: "s3", TmuxTarget: "work:2.0"},
+	}
+
+	var called []string
+	err := bulkEach(targets, func(s session.Session) error {
+		called = append(called, s.SessionID)
+		if s.SessionID == "s2" {
+			return errors.New("boom")
+		}
+		return nil
+	})
+
+	if len(called) != 3 {
+		t.Fatalf("expected all 3 targets to be attempted, got %v", called)
+	}
+	if err == nil {
+		t.Fatal("expected the first failure to be returned")
+	}
+}
@@ -0,0 +1,9 @@
+package tui
+
+// Exit codes for Query's headless filter mode, mirroring fzf --filter's
+// convention so scripts can branch on whether anything matched.
+const (
+	ExitMatched    = 0
+	ExitNoMatches  = 1
+	ExitQueryError = 2
+)
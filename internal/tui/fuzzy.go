@@ -0,0 +1,81 @@
+package tui
+
+import "strings"
+
+// fuzzyMatch is the result of scoring a candidate string against a fuzzy
+// query.
+type fuzzyMatch struct {
+	score     int
+	positions []int
+}
+
+// fuzzyScore scores candidate against query the way fzf does: every rune of
+// query must appear in candidate in order (case-insensitively) for ok to be
+// true. The score rewards matches at the very start of candidate, matches
+// right after a word boundary (/, -, _, ., space, or a camelCase transition),
+// and runs of consecutive matched runes, while penalizing matches that are
+// spread thinly across a long span of candidate. positions holds the rune
+// indices into candidate that matched, for highlighting.
+func fuzzyScore(query, candidate string) (match fuzzyMatch, ok bool) {
+	if query == "" {
+		return fuzzyMatch{}, true
+	}
+
+	q := []rune(strings.ToLower(query))
+	c := []rune(candidate)
+	cLower := []rune(strings.ToLower(candidate))
+
+	positions := make([]int, 0, len(q))
+	qi := 0
+	prevMatched := -2
+	score := 0
+
+	for ci := 0; ci < len(c) && qi < len(q); ci++ {
+		if cLower[ci] != q[qi] {
+			continue
+		}
+
+		points := 1
+		if ci == 0 {
+			points += 8
+		}
+		if isWordBoundary(c, ci) {
+			points += 6
+		}
+		if ci == prevMatched+1 {
+			points += 4
+		}
+
+		score += points
+		positions = append(positions, ci)
+		prevMatched = ci
+		qi++
+	}
+
+	if qi < len(q) {
+		return fuzzyMatch{}, false
+	}
+
+	span := positions[len(positions)-1] - positions[0] + 1
+	score -= span - len(positions)
+
+	return fuzzyMatch{score: score, positions: positions}, true
+}
+
+// isWordBoundary reports whether c[i] starts a new "word" within c: it's the
+// first rune, follows a separator, or is an uppercase rune following a
+// lowercase one (camelCase).
+func isWordBoundary(c []rune, i int) bool {
+	if i == 0 {
+		return true
+	}
+	switch c[i-1] {
+	case '/', '-', '_', '.', ' ':
+		return true
+	}
+	return isUpper(c[i]) && !isUpper(c[i-1])
+}
+
+func isUpper(r rune) bool {
+	return r >= 'A' && r <= 'Z'
+}
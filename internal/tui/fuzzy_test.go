@@ -0,0 +1,109 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/Jevs21/claude-tmux/internal/session"
+)
+
+func TestFuzzyScore_MatchesNonContiguousSubsequence(t *testing.T) {
+	_, ok := fuzzyScore("cltmx", "claude-tmux")
+	if !ok {
+		t.Fatalf("expected \"cltmx\" to subsequence-match \"claude-tmux\"")
+	}
+}
+
+func TestFuzzyScore_RejectsOutOfOrderQuery(t *testing.T) {
+	_, ok := fuzzyScore("xmtlc", "claude-tmux")
+	if ok {
+		t.Fatalf("expected a query whose runes appear out of order to not match")
+	}
+}
+
+func TestFuzzyScore_RanksPrefixAboveMidStringMatch(t *testing.T) {
+	prefix, ok := fuzzyScore("api", "api-server")
+	if !ok {
+		t.Fatalf("expected \"api\" to match \"api-server\"")
+	}
+	mid, ok := fuzzyScore("api", "claude-api")
+	if !ok {
+		t.Fatalf("expected \"api\" to match \"claude-api\"")
+	}
+	if prefix.score <= mid.score {
+		t.Fatalf("expected a prefix match to score higher than a mid-string match, got prefix=%d mid=%d", prefix.score, mid.score)
+	}
+}
+
+func TestFuzzyScore_RanksWordBoundaryAboveMidWordMatch(t *testing.T) {
+	boundary, ok := fuzzyScore("s", "api-server")
+	if !ok {
+		t.Fatalf("expected \"s\" to match \"api-server\"")
+	}
+	midWord, ok := fuzzyScore("v", "api-server")
+	if !ok {
+		t.Fatalf("expected \"v\" to match \"api-server\"")
+	}
+	if boundary.score <= midWord.score {
+		t.Fatalf("expected a word-boundary match to score higher than a mid-word match, got boundary=%d midWord=%d", boundary.score, midWord.score)
+	}
+}
+
+func TestFuzzyScore_ReportsMatchedPositions(t *testing.T) {
+	match, ok := fuzzyScore("cat", "concatenate")
+	if !ok {
+		t.Fatalf("expected \"cat\" to match \"concatenate\"")
+	}
+	want := []int{0, 4, 5}
+	if len(match.positions) != len(want) {
+		t.Fatalf("expected positions %v, got %v", want, match.positions)
+	}
+	for i, p := range want {
+		if match.positions[i] != p {
+			t.Fatalf("expected positions %v, got %v", want, match.positions)
+		}
+	}
+}
+
+func TestFuzzyScore_EmptyQueryMatchesEverything(t *testing.T) {
+	match, ok := fuzzyScore("", "anything")
+	if !ok || len(match.positions) != 0 {
+		t.Fatalf("expected an empty query to match with no highlighted positions, got %+v ok=%v", match, ok)
+	}
+}
+
+func TestApplyFilter_FuzzyModeDropsNonMatchesAndHighlightsMatch(t *testing.T) {
+	m := model{
+		sessions: []session.Session{
+			{SessionID: "s1", ProjectName: "claude-tmux"},
+			{SessionID: "s2", ProjectName: "api-server"},
+		},
+		filterText: "cltmx",
+		fuzzyMode:  true,
+	}
+	m.applyFilter()
+
+	if len(m.filtered) != 1 || m.filtered[0].SessionID != "s1" {
+		t.Fatalf("expected only claude-tmux to match \"cltmx\", got %+v", m.filtered)
+	}
+	if len(m.filterHighlights) != 1 || len(m.filterHighlights[0]) != 5 {
+		t.Fatalf("expected 5 highlighted positions for a 5-rune query, got %+v", m.filterHighlights)
+	}
+}
+
+func TestApplyFilter_SubstringModeIgnoresFuzzyOrdering(t *testing.T) {
+	m := model{
+		sessions: []session.Session{
+			{SessionID: "s1", ProjectName: "claude-tmux"},
+		},
+		filterText: "cltmx",
+		fuzzyMode:  false,
+	}
+	m.applyFilter()
+
+	if len(m.filtered) != 0 {
+		t.Fatalf("expected substring mode to reject a non-contiguous query, got %+v", m.filtered)
+	}
+	if m.filterHighlights != nil {
+		t.Fatalf("expected substring mode to produce no highlights, got %+v", m.filterHighlights)
+	}
+}
@@ -1,8 +1,12 @@
 package tui
 
 import (
+	"context"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Jevs21/claude-tmux/internal/session"
@@ -11,7 +15,10 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 )
 
-const refreshInterval = 750 * time.Millisecond
+// safetyNetInterval is the fallback poll period once session.WatchForChanges
+// is driving refreshes; it only matters for changes the watcher misses (a
+// new shard file appearing, say).
+const safetyNetInterval = 5 * time.Second
 const spinnerInterval = 150 * time.Millisecond
 
 // spinnerFrames are the characters used for the busy status animation,
@@ -24,21 +31,56 @@ type mode int
 const (
 	modeNormal mode = iota
 	modeFilter
+	modeAction
+	modeConfirm
+	modeRename
+	modeBulkAction
+	modeBulkConfirm
+	modeBulkKeys
+	modeSendConfirm
 )
 
 // model is the Bubbletea model for the session list TUI.
 type model struct {
-	sessions     []session.Session
-	filtered     []session.Session
-	cursor       int
-	mode         mode
-	filterInput  textinput.Model
-	filterText   string
-	err          error
-	width        int
-	height       int
-	jumpTarget   string // set when user selects a session to jump to
-	spinnerFrame int    // current index into spinnerFrames for busy animation
+	sessions         []session.Session
+	filtered         []session.Session
+	filterHighlights [][]int // matched rune indices into filtered[i].ProjectName, parallel to filtered; nil entries/slice mean no highlight
+	fuzzyMode        bool    // fuzzy subsequence matching vs. plain substring, toggled with ctrl+f
+	cursor           int
+	mode             mode
+	filterInput      textinput.Model
+	filterText       string
+	err              error
+	width            int
+	height           int
+	jumpTarget       string // set when user selects a session to jump to
+	spinnerFrame     int    // current index into spinnerFrames for busy animation
+
+	actionTarget  session.Session // session the action overlay (modeAction/modeConfirm/modeRename) applies to
+	actionCursor  int             // selected row within the action overlay
+	pendingAction tuiAction       // destructive action awaiting modeConfirm's y/n
+	actionErr     error           // result of the last action, shown until the next one runs
+	renameInput   textinput.Model
+
+	changes <-chan struct{} // delivers from session.WatchForChanges once the watcher has started; nil until then
+
+	previewEnabled    bool // toggled with "p"
+	previewCache      map[string]previewEntry
+	previewGeneration int // incremented each time a capture is (re)scheduled, so stale debounce/capture results can be dropped
+
+	selected map[string]struct{} // keyed by SessionID rather than filtered-slice index, so it survives refresh/re-sort and filter changes
+
+	bulkTargets       []session.Session // the selected sessions the bulk overlay (modeBulkAction/modeBulkConfirm/modeBulkKeys) applies to
+	bulkCursor        int               // selected row within the bulk action overlay
+	pendingBulkAction tuiBulkAction     // destructive bulk action awaiting modeBulkConfirm's y/n
+	bulkErr           error             // result of the last bulk action, shown until the next one runs
+	keysInput         textinput.Model
+
+	prefs            Prefs           // loaded once at startup from ~/.claude-tmux/tui.toml
+	autoAnswered     map[string]bool // SessionIDs already auto-confirmed, so a still-waiting session isn't re-sent option 1 every tick
+	sendTarget       session.Session // session the modeSendConfirm modal applies to
+	sendOptionNumber int             // option number awaiting modeSendConfirm's y/n
+	sendOptionText   string          // that option's label, for display
 }
 
 // sessionsMsg carries the result of an async session read.
@@ -47,12 +89,23 @@ type sessionsMsg struct {
 	err      error
 }
 
-// tickMsg triggers a periodic refresh.
+// tickMsg triggers the safety-net refresh, for changes session.WatchForChanges
+// missed.
 type tickMsg time.Time
 
 // spinnerTickMsg triggers a spinner frame advance.
 type spinnerTickMsg time.Time
 
+// watcherStartedMsg carries the channel session.WatchForChanges returned,
+// once the background watcher goroutines are up and running.
+type watcherStartedMsg struct {
+	changes <-chan struct{}
+}
+
+// sessionsChangedMsg signals that a watched event shard or the tmux hook
+// sentinel received a new line, so Update should rescan.
+type sessionsChangedMsg struct{}
+
 // scanCmd reads sessions from the event log.
 func scanCmd() tea.Msg {
 	sessions, err := session.ReadSessions()
@@ -62,13 +115,89 @@ func scanCmd() tea.Msg {
 	return sessionsMsg{sessions: sessions}
 }
 
-// tickCmd returns a command that sends a tickMsg after the refresh interval.
+// tickCmd returns a command that sends a tickMsg after the safety-net interval.
 func tickCmd() tea.Cmd {
-	return tea.Tick(refreshInterval, func(t time.Time) tea.Msg {
+	return tea.Tick(safetyNetInterval, func(t time.Time) tea.Msg {
 		return tickMsg(t)
 	})
 }
 
+// startWatcherCmd starts session.WatchForChanges and also installs the tmux
+// hooks that feed it, returning the resulting channel as a watcherStartedMsg.
+// If either step fails (no tmux on PATH, HOME unset in some sandbox, etc.)
+// the model just keeps relying on the safety-net tick. It also starts a tmux
+// control-mode client if one's available, merging its notifications in
+// alongside the hook-driven changes so pane/window/session events push a
+// rescan too instead of waiting for the next tick.
+func startWatcherCmd() tea.Msg {
+	changes, err := session.WatchForChanges(context.Background())
+	if err != nil {
+		return watcherStartedMsg{}
+	}
+	if sentinel, err := session.HookSentinelPath(); err == nil {
+		_ = tmux.InstallRefreshHooks(sentinel)
+	}
+	return watcherStartedMsg{changes: mergeChangeChannels(changes, startController())}
+}
+
+// startController starts a tmux control-mode client for session.MapPanes and
+// session.CaptureStatuses to submit commands through, and returns the
+// channel its notifications feed into. It returns nil if control mode isn't
+// available (no tmux on PATH, say), leaving the existing one-shot exec path
+// and safety-net tick as the only refresh sources.
+func startController() <-chan struct{} {
+	controller, err := tmux.NewController()
+	if err != nil {
+		return nil
+	}
+	session.ActiveController = controller
+	return session.WatchControllerNotifications()
+}
+
+// mergeChangeChannels fans multiple change-notification channels into one,
+// coalescing the same way each input already does: callers only care that
+// something changed, not how many times or from which source. A nil input
+// channel (e.g. startController's when control mode is unavailable) is
+// skipped rather than blocking forever.
+func mergeChangeChannels(channels ...<-chan struct{}) <-chan struct{} {
+	merged := make(chan struct{}, 1)
+	var wg sync.WaitGroup
+	for _, ch := range channels {
+		if ch == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(ch <-chan struct{}) {
+			defer wg.Done()
+			for range ch {
+				select {
+				case merged <- struct{}{}:
+				default:
+				}
+			}
+		}(ch)
+	}
+	go func() {
+		wg.Wait()
+		close(merged)
+	}()
+	return merged
+}
+
+// waitForChangeCmd blocks until changes delivers (or is closed), then
+// returns a sessionsChangedMsg so Update can rescan and keep listening.
+func waitForChangeCmd(changes <-chan struct{}) tea.Cmd {
+	return func() tea.Msg {
+		if changes == nil {
+			return nil
+		}
+		if _, ok := <-changes; !ok {
+			return nil
+		}
+		return sessionsChangedMsg{}
+	}
+}
+
 // spinnerTickCmd returns a command that sends a spinnerTickMsg for animation.
 func spinnerTickCmd() tea.Cmd {
 	return tea.Tick(spinnerInterval, func(t time.Time) tea.Msg {
@@ -81,13 +210,28 @@ func initialModel() model {
 	filterInput.Placeholder = "filter sessions..."
 	filterInput.CharLimit = 64
 
+	renameInput := textinput.New()
+	renameInput.Placeholder = "new window name..."
+	renameInput.CharLimit = 64
+
+	keysInput := textinput.New()
+	keysInput.Placeholder = "keys to send (tmux send-keys syntax)..."
+	keysInput.CharLimit = 256
+
+	prefs, _ := LoadPrefs() // missing/invalid prefs just leave auto-confirm off
+	_ = initTheme()         // unset/unknown $CLAUDE_TMUX_THEME just leaves the default palette
+
 	return model{
 		filterInput: filterInput,
+		renameInput: renameInput,
+		keysInput:   keysInput,
+		fuzzyMode:   true,
+		prefs:       prefs,
 	}
 }
 
 func (m model) Init() tea.Cmd {
-	return tea.Batch(scanCmd, tickCmd(), spinnerTickCmd())
+	return tea.Batch(scanCmd, startWatcherCmd, tickCmd(), spinnerTickCmd())
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -106,12 +250,30 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.err = nil
 		previousSessionID := m.selectedSessionID()
 		m.sessions = msg.sessions
+		session.MarkPrevious(m.sessions)
 		m.applyFilter()
 		m.restoreCursorBySessionID(previousSessionID)
-		return m, nil
+		return m, m.autoConfirmSafeCmd()
 
 	case tickMsg:
-		return m, tea.Batch(scanCmd, tickCmd())
+		return m, tea.Batch(scanCmd, tickCmd(), m.requestPreviewForHighlighted())
+
+	case previewDebounceMsg:
+		if msg.generation != m.previewGeneration {
+			return m, nil
+		}
+		return m, capturePreviewCmd(msg.generation, msg.sessionID, msg.target)
+
+	case previewMsg:
+		m.storePreview(msg)
+		return m, nil
+
+	case watcherStartedMsg:
+		m.changes = msg.changes
+		return m, waitForChangeCmd(m.changes)
+
+	case sessionsChangedMsg:
+		return m, tea.Batch(scanCmd, waitForChangeCmd(m.changes))
 
 	case spinnerTickMsg:
 		m.spinnerFrame = (m.spinnerFrame + 1) % len(spinnerFrames)
@@ -123,6 +285,20 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.updateNormal(msg)
 		case modeFilter:
 			return m.updateFilter(msg)
+		case modeAction:
+			return m.updateAction(msg)
+		case modeConfirm:
+			return m.updateConfirm(msg)
+		case modeRename:
+			return m.updateRename(msg)
+		case modeBulkAction:
+			return m.updateBulkAction(msg)
+		case modeBulkConfirm:
+			return m.updateBulkConfirm(msg)
+		case modeBulkKeys:
+			return m.updateBulkKeys(msg)
+		case modeSendConfirm:
+			return m.updateSendConfirm(msg)
 		}
 	}
 
@@ -131,20 +307,46 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (m model) updateNormal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
-	case "q", "esc", "ctrl+c":
+	case "q", "ctrl+c":
+		return m, tea.Quit
+
+	case "esc":
+		if len(m.selected) > 0 {
+			m.selected = nil
+			return m, nil
+		}
 		return m, tea.Quit
 
+	case " ":
+		if len(m.filtered) == 0 {
+			return m, nil
+		}
+		m.toggleSelected(m.filtered[m.cursor].SessionID)
+		return m, nil
+
+	case "*":
+		m.selectAllFiltered()
+		return m, nil
+
+	case "i": // invert selection; "-" is already bound to jump-to-previous
+		m.invertSelection()
+		return m, nil
+
 	case "j", "down":
 		if m.cursor < len(m.filtered)-1 {
 			m.cursor++
 		}
-		return m, nil
+		return m, m.requestPreviewForHighlighted()
 
 	case "k", "up":
 		if m.cursor > 0 {
 			m.cursor--
 		}
-		return m, nil
+		return m, m.requestPreviewForHighlighted()
+
+	case "p":
+		m.previewEnabled = !m.previewEnabled
+		return m, m.requestPreviewForHighlighted()
 
 	case "enter":
 		if len(m.filtered) > 0 && m.filtered[m.cursor].Jumpable() {
@@ -153,26 +355,196 @@ func (m model) updateNormal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case "-":
+		if prev, err := session.Previous(); err == nil && prev != nil {
+			m.jumpTarget = prev.TmuxTarget
+			return m, tea.Quit
+		}
+		return m, nil
+
 	case "/":
 		m.mode = modeFilter
 		m.filterInput.SetValue(m.filterText)
 		cmd := m.filterInput.Focus()
 		return m, cmd
 
+	case "ctrl+f":
+		m.fuzzyMode = !m.fuzzyMode
+		m.applyFilter()
+		m.clampCursor()
+		return m, nil
+
+	case "a":
+		if len(m.selected) > 0 {
+			targets := m.selectedSessions()
+			if len(targets) == 0 {
+				return m, nil
+			}
+			m.bulkTargets = targets
+			m.bulkCursor = 0
+			m.bulkErr = nil
+			m.mode = modeBulkAction
+			return m, nil
+		}
+		if len(m.filtered) == 0 {
+			return m, nil
+		}
+		target := m.filtered[m.cursor]
+		if actionsForSession(target) == nil {
+			return m, nil
+		}
+		m.actionTarget = target
+		m.actionCursor = 0
+		m.actionErr = nil
+		m.mode = modeAction
+		return m, nil
+
 	case "G":
 		if len(m.filtered) > 0 {
 			m.cursor = len(m.filtered) - 1
 		}
-		return m, nil
+		return m, m.requestPreviewForHighlighted()
 
 	case "g":
 		m.cursor = 0
+		return m, m.requestPreviewForHighlighted()
+
+	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		if n, err := strconv.Atoi(msg.String()); err == nil {
+			return m.beginSendOption(n)
+		}
 		return m, nil
+
+	case "y":
+		return m.beginSendYesNo(true)
+
+	case "n":
+		return m.beginSendYesNo(false)
 	}
 
 	return m, nil
 }
 
+// beginSendOption captures the highlighted StatusWaiting session's current
+// pane content and, if option n exists among its parsed menu, drops into
+// modeSendConfirm to ask before sending it. Rows that aren't waiting, or a
+// menu that no longer has option n (the pane moved on since it was last
+// captured), leave the key without effect.
+func (m model) beginSendOption(n int) (tea.Model, tea.Cmd) {
+	if len(m.filtered) == 0 {
+		return m, nil
+	}
+	target := m.filtered[m.cursor]
+	if target.Status != session.StatusWaiting || target.TmuxTarget == "" {
+		return m, nil
+	}
+
+	for _, opt := range session.CaptureWaitingOptions(target.TmuxTarget) {
+		if opt.Number == n {
+			m.sendTarget = target
+			m.sendOptionNumber = opt.Number
+			m.sendOptionText = opt.Text
+			m.mode = modeSendConfirm
+			return m, nil
+		}
+	}
+	return m, nil
+}
+
+// beginSendYesNo maps a y/n keypress to whichever option number
+// session.YesOption/session.NoOption parsed out of the highlighted session's
+// current waiting menu, then defers to beginSendOption.
+func (m model) beginSendYesNo(yes bool) (tea.Model, tea.Cmd) {
+	if len(m.filtered) == 0 {
+		return m, nil
+	}
+	target := m.filtered[m.cursor]
+	if target.Status != session.StatusWaiting || target.TmuxTarget == "" {
+		return m, nil
+	}
+
+	options := session.CaptureWaitingOptions(target.TmuxTarget)
+	var n int
+	var ok bool
+	if yes {
+		n, ok = session.YesOption(options)
+	} else {
+		n, ok = session.NoOption(options)
+	}
+	if !ok {
+		return m, nil
+	}
+	return m.beginSendOption(n)
+}
+
+// updateSendConfirm handles the y/n confirmation sub-prompt shown before
+// sending a numbered option to a StatusWaiting session's pane.
+func (m model) updateSendConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "enter":
+		return m.runSendOption()
+	case "n", "esc", "ctrl+c":
+		m.mode = modeNormal
+		return m, nil
+	}
+	return m, nil
+}
+
+// runSendOption sends m.sendOptionNumber to m.sendTarget's pane and returns
+// to the session list, the same "execute then rescan" shape runAction uses.
+func (m model) runSendOption() (tea.Model, tea.Cmd) {
+	m.actionErr = session.SubmitKeys(m.sendTarget.TmuxTarget, strconv.Itoa(m.sendOptionNumber))
+	m.mode = modeNormal
+	return m, scanCmd
+}
+
+// autoConfirmSafeCmd returns a command that auto-sends option 1 to every
+// StatusWaiting session whose pane content matches one of m.prefs'
+// SafeQuestions and hasn't already been auto-answered, when AutoConfirmSafe
+// is enabled. Sessions no longer waiting are forgotten, so a later prompt
+// gets auto-answered again instead of being silently skipped forever.
+func (m *model) autoConfirmSafeCmd() tea.Cmd {
+	if !m.prefs.AutoConfirmSafe {
+		return nil
+	}
+	if m.autoAnswered == nil {
+		m.autoAnswered = make(map[string]bool)
+	}
+
+	stillWaiting := make(map[string]bool, len(m.sessions))
+	var cmds []tea.Cmd
+	for _, s := range m.sessions {
+		if s.Status != session.StatusWaiting {
+			continue
+		}
+		stillWaiting[s.SessionID] = true
+		if s.TmuxTarget == "" || m.autoAnswered[s.SessionID] {
+			continue
+		}
+		if !m.prefs.matchesSafeQuestion(session.CapturePaneContent(s.TmuxTarget)) {
+			continue
+		}
+
+		m.autoAnswered[s.SessionID] = true
+		target := s.TmuxTarget
+		cmds = append(cmds, func() tea.Msg {
+			_ = session.SubmitKeys(target, "1")
+			return nil
+		})
+	}
+
+	for id := range m.autoAnswered {
+		if !stillWaiting[id] {
+			delete(m.autoAnswered, id)
+		}
+	}
+
+	if len(cmds) == 0 {
+		return nil
+	}
+	return tea.Batch(cmds...)
+}
+
 func (m model) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "esc":
@@ -200,6 +572,12 @@ func (m model) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "ctrl+c":
 		return m, tea.Quit
+
+	case "ctrl+f":
+		m.fuzzyMode = !m.fuzzyMode
+		m.applyFilter()
+		m.clampCursor()
+		return m, nil
 	}
 
 	// Pass keystrokes to the text input
@@ -211,12 +589,229 @@ func (m model) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// updateAction handles key input while the action overlay (triggered by "a"
+// in updateNormal) is open.
+func (m model) updateAction(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	actions := actionsForSession(m.actionTarget)
+
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.mode = modeNormal
+		return m, nil
+
+	case "j", "down":
+		if m.actionCursor < len(actions)-1 {
+			m.actionCursor++
+		}
+		return m, nil
+
+	case "k", "up":
+		if m.actionCursor > 0 {
+			m.actionCursor--
+		}
+		return m, nil
+
+	case "enter":
+		return m.selectAction(actions, m.actionCursor)
+	}
+
+	if n, err := strconv.Atoi(msg.String()); err == nil {
+		return m.selectAction(actions, n-1)
+	}
+
+	return m, nil
+}
+
+// selectAction activates actions[index]: destructive actions drop into
+// modeConfirm first, everything else runs immediately.
+func (m model) selectAction(actions []tuiAction, index int) (tea.Model, tea.Cmd) {
+	if index < 0 || index >= len(actions) {
+		return m, nil
+	}
+
+	action := actions[index]
+	if action.destructive {
+		m.mode = modeConfirm
+		m.pendingAction = action
+		return m, nil
+	}
+	return m.runAction(action)
+}
+
+// updateConfirm handles the y/n confirmation sub-prompt for a destructive
+// action.
+func (m model) updateConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "enter":
+		return m.runAction(m.pendingAction)
+	case "n", "esc", "ctrl+c":
+		m.mode = modeAction
+		return m, nil
+	}
+	return m, nil
+}
+
+// updateBulkAction handles key input while the bulk action overlay
+// (triggered by "a" in updateNormal when a selection is active) is open.
+func (m model) updateBulkAction(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	actions := bulkActionsForSelection()
+
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.mode = modeNormal
+		return m, nil
+
+	case "j", "down":
+		if m.bulkCursor < len(actions)-1 {
+			m.bulkCursor++
+		}
+		return m, nil
+
+	case "k", "up":
+		if m.bulkCursor > 0 {
+			m.bulkCursor--
+		}
+		return m, nil
+
+	case "enter":
+		return m.selectBulkAction(actions, m.bulkCursor)
+	}
+
+	if n, err := strconv.Atoi(msg.String()); err == nil {
+		return m.selectBulkAction(actions, n-1)
+	}
+
+	return m, nil
+}
+
+// selectBulkAction activates actions[index] against m.bulkTargets:
+// destructive actions drop into modeBulkConfirm first, everything else runs
+// immediately.
+func (m model) selectBulkAction(actions []tuiBulkAction, index int) (tea.Model, tea.Cmd) {
+	if index < 0 || index >= len(actions) {
+		return m, nil
+	}
+
+	action := actions[index]
+	if action.destructive {
+		m.mode = modeBulkConfirm
+		m.pendingBulkAction = action
+		return m, nil
+	}
+	return m.runBulkAction(action)
+}
+
+// updateBulkConfirm handles the y/n confirmation sub-prompt for a
+// destructive bulk action.
+func (m model) updateBulkConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "enter":
+		return m.runBulkAction(m.pendingBulkAction)
+	case "n", "esc", "ctrl+c":
+		m.mode = modeBulkAction
+		return m, nil
+	}
+	return m, nil
+}
+
+// updateBulkKeys handles text input for the Send keys to all bulk action.
+func (m model) updateBulkKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.keysInput.Blur()
+		m.mode = modeNormal
+		return m, nil
+
+	case "enter":
+		keys := m.keysInput.Value()
+		m.keysInput.Blur()
+		m.mode = modeNormal
+		if keys == "" {
+			return m, nil
+		}
+		m.bulkErr = sendKeysToAll(m.bulkTargets, keys)
+		return m, scanCmd
+	}
+
+	var cmd tea.Cmd
+	m.keysInput, cmd = m.keysInput.Update(msg)
+	return m, cmd
+}
+
+// runBulkAction carries out action against m.bulkTargets. bulkSendKeys needs
+// special handling to open the keys text prompt first; every other action
+// just runs and returns to the session list, with its error (if any)
+// surfaced by View until the next bulk action runs.
+func (m model) runBulkAction(action tuiBulkAction) (tea.Model, tea.Cmd) {
+	if action.kind == bulkSendKeys {
+		m.keysInput.SetValue("")
+		cmd := m.keysInput.Focus()
+		m.mode = modeBulkKeys
+		return m, cmd
+	}
+
+	m.bulkErr = executeBulkAction(action.kind, m.bulkTargets)
+	m.mode = modeNormal
+	return m, scanCmd
+}
+
+// updateRename handles text input for the Rename window action.
+func (m model) updateRename(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.renameInput.Blur()
+		m.mode = modeNormal
+		return m, nil
+
+	case "enter":
+		name := strings.TrimSpace(m.renameInput.Value())
+		m.renameInput.Blur()
+		m.mode = modeNormal
+		if name == "" {
+			return m, nil
+		}
+		m.actionErr = tmux.RenameWindow(windowTarget(m.actionTarget), name)
+		return m, scanCmd
+	}
+
+	var cmd tea.Cmd
+	m.renameInput, cmd = m.renameInput.Update(msg)
+	return m, cmd
+}
+
+// runAction carries out action against m.actionTarget. Attach and Rename
+// need special handling (quitting to jump, and opening a text prompt,
+// respectively); every other action just shells out via executeAction and
+// returns to the session list, with its error (if any) surfaced by View
+// until the next action runs.
+func (m model) runAction(action tuiAction) (tea.Model, tea.Cmd) {
+	switch action.kind {
+	case actionAttach:
+		m.jumpTarget = m.actionTarget.TmuxTarget
+		m.mode = modeNormal
+		return m, tea.Quit
+
+	case actionRename:
+		m.renameInput.SetValue("")
+		cmd := m.renameInput.Focus()
+		m.mode = modeRename
+		return m, cmd
+	}
+
+	m.actionErr = executeAction(action.kind, m.actionTarget)
+	m.mode = modeNormal
+	return m, scanCmd
+}
+
 func (m model) View() string {
 	var builder strings.Builder
 
 	// Header
 	sessionCount := len(m.filtered)
 	headerText := fmt.Sprintf("Claude Sessions (%d)", sessionCount)
+	if len(m.selected) > 0 {
+		headerText += fmt.Sprintf(" — %d selected", len(m.selected))
+	}
 	builder.WriteString(headerStyle.Render(headerText))
 	builder.WriteString("\n")
 
@@ -224,13 +819,53 @@ func (m model) View() string {
 	if m.mode == modeFilter {
 		builder.WriteString(filterPromptStyle.Render("/ "))
 		builder.WriteString(m.filterInput.View())
+		builder.WriteString("  ")
+		builder.WriteString(helpStyle.Render(m.filterModeLabel()))
 		builder.WriteString("\n\n")
 	} else if m.filterText != "" {
 		builder.WriteString(filterPromptStyle.Render("filter: "))
 		builder.WriteString(helpStyle.Render(m.filterText))
+		builder.WriteString("  ")
+		builder.WriteString(helpStyle.Render(m.filterModeLabel()))
 		builder.WriteString("\n\n")
 	}
 
+	// Action overlay: a numbered menu, its y/n confirmation, or the rename
+	// text prompt take over the rest of the screen while active.
+	switch m.mode {
+	case modeAction:
+		builder.WriteString(m.renderActionMenu())
+		return builder.String()
+	case modeConfirm:
+		builder.WriteString(m.renderConfirmPrompt())
+		return builder.String()
+	case modeRename:
+		builder.WriteString(m.renderRenamePrompt())
+		return builder.String()
+	case modeBulkAction:
+		builder.WriteString(m.renderBulkActionMenu())
+		return builder.String()
+	case modeBulkConfirm:
+		builder.WriteString(m.renderBulkConfirmPrompt())
+		return builder.String()
+	case modeBulkKeys:
+		builder.WriteString(m.renderBulkKeysPrompt())
+		return builder.String()
+	case modeSendConfirm:
+		builder.WriteString(m.renderSendConfirmPrompt())
+		return builder.String()
+	}
+
+	if m.actionErr != nil {
+		builder.WriteString(emptyStyle.Render(fmt.Sprintf("Action failed: %v", m.actionErr)))
+		builder.WriteString("\n")
+	}
+
+	if m.bulkErr != nil {
+		builder.WriteString(emptyStyle.Render(fmt.Sprintf("Bulk action failed: %v", m.bulkErr)))
+		builder.WriteString("\n")
+	}
+
 	// Error state
 	if m.err != nil {
 		builder.WriteString(emptyStyle.Render(fmt.Sprintf("Error: %v", m.err)))
@@ -272,13 +907,13 @@ func (m model) View() string {
 
 		// Render status indicator
 		statusIndicator := m.renderStatusIndicator(s.Status, isSelected)
+		selectionMarker := m.renderSelectionMarker(s)
+
+		projectName := padRight(renderProjectName(s.ProjectName, m.highlightFor(i), isSelected), maxProjectWidth-len([]rune(s.ProjectName)))
 
 		var line string
 		if isSelected {
 			cursor := cursorStyle.Render("> ")
-			projectName := projectSelectedStyle.
-				Width(maxProjectWidth).
-				Render(s.ProjectName)
 			target := tmuxTargetSelectedStyle.
 				Width(maxTargetWidth).
 				Render(s.DisplayTarget())
@@ -288,7 +923,7 @@ func (m model) View() string {
 					Render(s.DisplayTarget())
 			}
 			displayPath := pathSelectedStyle.Render(s.DisplayPath())
-			line = cursor + statusIndicator + projectName + "  " + target + "  " + displayPath
+			line = cursor + selectionMarker + statusIndicator + projectName + "  " + target + "  " + displayPath
 
 			if s.Action != "" {
 				actionText := actionSelectedStyle.
@@ -298,9 +933,6 @@ func (m model) View() string {
 			}
 		} else {
 			cursor := "  "
-			projectName := projectStyle.
-				Width(maxProjectWidth).
-				Render(s.ProjectName)
 			target := tmuxTargetStyle.
 				Width(maxTargetWidth).
 				Render(s.DisplayTarget())
@@ -310,7 +942,7 @@ func (m model) View() string {
 					Render(s.DisplayTarget())
 			}
 			displayPath := pathStyle.Render(s.DisplayPath())
-			line = cursor + statusIndicator + projectName + "  " + target + "  " + displayPath
+			line = cursor + selectionMarker + statusIndicator + projectName + "  " + target + "  " + displayPath
 
 			if s.Action != "" {
 				actionText := actionStyle.
@@ -327,12 +959,163 @@ func (m model) View() string {
 	// Footer help
 	builder.WriteString("\n")
 	if m.mode == modeFilter {
-		builder.WriteString(helpStyle.Render("enter: jump  esc: clear filter  ctrl+c: quit"))
+		builder.WriteString(helpStyle.Render("enter: jump  esc: clear filter  ctrl+f: toggle fuzzy  ctrl+c: quit"))
+	} else if len(m.selected) > 0 {
+		builder.WriteString(helpStyle.Render("space: toggle  *: select all  i: invert  a: bulk actions  esc: clear  q: quit"))
+	} else if len(m.filtered) > 0 && m.filtered[m.cursor].Status == session.StatusWaiting {
+		builder.WriteString(helpStyle.Render("1-9/y/n: answer prompt  enter: jump  a: actions  space: select  /: filter  q: quit"))
 	} else {
-		builder.WriteString(helpStyle.Render("j/k: navigate  enter: jump  /: filter  q: quit"))
+		builder.WriteString(helpStyle.Render("j/k: navigate  enter: jump  a: actions  space: select  p: preview  -: previous  /: filter  ctrl+f: toggle fuzzy  q: quit"))
+	}
+
+	listStr := builder.String()
+	if !m.previewEnabled {
+		return listStr
+	}
+	return m.withPreviewPanel(listStr)
+}
+
+// renderActionMenu renders the numbered action overlay for m.actionTarget, a
+// ❯-prefixed selector on the highlighted row mirroring how Claude Code's own
+// permission prompts look (see session.detectStatus's numbered-option
+// detection).
+func (m model) renderActionMenu() string {
+	var b strings.Builder
+	b.WriteString(headerStyle.Render(fmt.Sprintf("Actions: %s (%s)", m.actionTarget.ProjectName, m.actionTarget.DisplayTarget())))
+	b.WriteString("\n\n")
+
+	for i, action := range actionsForSession(m.actionTarget) {
+		line := fmt.Sprintf("%d. %s", i+1, action.label)
+		if i == m.actionCursor {
+			b.WriteString(cursorStyle.Render("❯ " + line))
+		} else {
+			b.WriteString(normalStyle.Render("  " + line))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("j/k: navigate  enter: select  1-9: select  esc: cancel"))
+	return b.String()
+}
+
+// renderConfirmPrompt renders the y/n confirmation sub-prompt shown before a
+// destructive action runs.
+func (m model) renderConfirmPrompt() string {
+	var b strings.Builder
+	b.WriteString(headerStyle.Render("Confirm"))
+	b.WriteString("\n\n")
+	b.WriteString(fmt.Sprintf("%s %s?", m.pendingAction.label, m.actionTarget.DisplayTarget()))
+	b.WriteString("\n\n")
+	b.WriteString(helpStyle.Render("y: confirm  n/esc: cancel"))
+	return b.String()
+}
+
+// renderSendConfirmPrompt renders the y/n confirmation shown before sending
+// a numbered option to a StatusWaiting session's pane.
+func (m model) renderSendConfirmPrompt() string {
+	var b strings.Builder
+	b.WriteString(headerStyle.Render("Send option"))
+	b.WriteString("\n\n")
+	question := fmt.Sprintf("Send %d (%s) to %s?", m.sendOptionNumber, m.sendOptionText, m.sendTarget.DisplayTarget())
+	b.WriteString(filterPromptStyle.Render(question))
+	b.WriteString("\n\n")
+	b.WriteString(helpStyle.Render("y: confirm  n/esc: cancel"))
+	return b.String()
+}
+
+// renderRenamePrompt renders the text prompt for the Rename window action.
+func (m model) renderRenamePrompt() string {
+	var b strings.Builder
+	b.WriteString(headerStyle.Render(fmt.Sprintf("Rename window: %s", m.actionTarget.DisplayTarget())))
+	b.WriteString("\n\n")
+	b.WriteString(filterPromptStyle.Render("> "))
+	b.WriteString(m.renameInput.View())
+	b.WriteString("\n\n")
+	b.WriteString(helpStyle.Render("enter: rename  esc: cancel"))
+	return b.String()
+}
+
+// renderBulkActionMenu renders the numbered bulk action overlay for
+// m.bulkTargets.
+func (m model) renderBulkActionMenu() string {
+	var b strings.Builder
+	b.WriteString(headerStyle.Render(fmt.Sprintf("Bulk actions: %d selected", len(m.bulkTargets))))
+	b.WriteString("\n\n")
+
+	for i, action := range bulkActionsForSelection() {
+		line := fmt.Sprintf("%d. %s", i+1, action.label)
+		if i == m.bulkCursor {
+			b.WriteString(cursorStyle.Render("❯ " + line))
+		} else {
+			b.WriteString(normalStyle.Render("  " + line))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("j/k: navigate  enter: select  1-9: select  esc: cancel"))
+	return b.String()
+}
+
+// renderBulkConfirmPrompt renders the y/n confirmation sub-prompt shown
+// before a destructive bulk action runs.
+func (m model) renderBulkConfirmPrompt() string {
+	var b strings.Builder
+	b.WriteString(headerStyle.Render("Confirm"))
+	b.WriteString("\n\n")
+	b.WriteString(fmt.Sprintf("%s %d selected session(s)?", m.pendingBulkAction.label, len(m.bulkTargets)))
+	b.WriteString("\n\n")
+	b.WriteString(helpStyle.Render("y: confirm  n/esc: cancel"))
+	return b.String()
+}
+
+// renderBulkKeysPrompt renders the text prompt for the Send keys to all bulk
+// action.
+func (m model) renderBulkKeysPrompt() string {
+	var b strings.Builder
+	b.WriteString(headerStyle.Render(fmt.Sprintf("Send keys to %d selected session(s)", len(m.bulkTargets))))
+	b.WriteString("\n\n")
+	b.WriteString(filterPromptStyle.Render("> "))
+	b.WriteString(m.keysInput.View())
+	b.WriteString("\n\n")
+	b.WriteString(helpStyle.Render("enter: send  esc: cancel"))
+	return b.String()
+}
+
+// filterModeLabel describes the active filter mode for display alongside the
+// filter input.
+func (m model) filterModeLabel() string {
+	if m.fuzzyMode {
+		return "[fuzzy]"
 	}
+	return "[substring]"
+}
 
-	return builder.String()
+// padRight right-pads s with n spaces, or returns s unchanged if n isn't
+// positive. s may already contain ANSI styling; the padding itself is left
+// unstyled since it's never visible.
+func padRight(s string, n int) string {
+	if n <= 0 {
+		return s
+	}
+	return s + strings.Repeat(" ", n)
+}
+
+// renderSelectionMarker returns a "[x] "/"[ ] " checkbox for s's place in the
+// selection, dimmed if s is selected but no longer Jumpable (its tmux pane
+// disappeared since it was selected).
+func (m model) renderSelectionMarker(s session.Session) string {
+	_, selected := m.selected[s.SessionID]
+	marker := "[ ]"
+	if selected {
+		marker = "[x]"
+	}
+	style := selectionMarkerStyle
+	if selected && !s.Jumpable() {
+		style = selectionMarkerDimStyle
+	}
+	return style.Render(marker) + " "
 }
 
 // renderStatusIndicator returns a styled status character with a trailing space.
@@ -362,16 +1145,48 @@ func (m model) renderStatusIndicator(status session.Status, isSelected bool) str
 	}
 }
 
-// applyFilter updates the filtered session list based on the current filter text.
+// applyFilter updates the filtered session list based on the current filter
+// text. By default it fuzzy-matches like fzf, so "cltmx" finds
+// "claude-tmux", ranking sessions by match quality; ctrl+f falls back to the
+// plain case-insensitive substring match.
 func (m *model) applyFilter() {
 	if m.filterText == "" {
 		m.filtered = m.sessions
+		m.filterHighlights = nil
 		return
 	}
 
-	filterLower := strings.ToLower(m.filterText)
+	if !m.fuzzyMode {
+		m.filtered = filterSubstring(m.sessions, m.filterText)
+		m.filterHighlights = nil
+		return
+	}
+
+	m.filtered, m.filterHighlights = filterFuzzy(m.sessions, m.filterText)
+}
+
+// filterSessions returns the sessions in sessions matching filterText, using
+// the same scoring model.applyFilter does: fuzzy subsequence ranking when
+// fuzzy is true, plain case-insensitive substring otherwise. An empty
+// filterText matches everything. Query uses this so a headless run ranks
+// sessions identically to the interactive filter.
+func filterSessions(sessions []session.Session, filterText string, fuzzy bool) []session.Session {
+	if filterText == "" {
+		return sessions
+	}
+	if !fuzzy {
+		return filterSubstring(sessions, filterText)
+	}
+	filtered, _ := filterFuzzy(sessions, filterText)
+	return filtered
+}
+
+// filterSubstring returns the sessions whose ProjectName, TmuxTarget, or
+// WorkDir contains filterText, case-insensitively.
+func filterSubstring(sessions []session.Session, filterText string) []session.Session {
+	filterLower := strings.ToLower(filterText)
 	var filtered []session.Session
-	for _, s := range m.sessions {
+	for _, s := range sessions {
 		searchText := strings.ToLower(
 			s.ProjectName + " " + s.TmuxTarget + " " + s.WorkDir,
 		)
@@ -379,7 +1194,97 @@ func (m *model) applyFilter() {
 			filtered = append(filtered, s)
 		}
 	}
-	m.filtered = filtered
+	return filtered
+}
+
+// filterFuzzy fuzzy-matches sessions against filterText, ranking them by
+// match quality, and returns the matches alongside the matched rune
+// positions within each session's ProjectName (parallel slices, for View's
+// highlighting).
+func filterFuzzy(sessions []session.Session, filterText string) ([]session.Session, [][]int) {
+	type candidate struct {
+		session   session.Session
+		highlight []int
+		score     int
+	}
+	var candidates []candidate
+	for _, s := range sessions {
+		searchText := s.ProjectName + " " + s.TmuxTarget + " " + s.WorkDir
+		match, ok := fuzzyScore(filterText, searchText)
+		if !ok {
+			continue
+		}
+		candidates = append(candidates, candidate{
+			session:   s,
+			highlight: projectHighlight(match.positions, len(s.ProjectName)),
+			score:     match.score,
+		})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score > candidates[j].score
+	})
+
+	filtered := make([]session.Session, len(candidates))
+	highlights := make([][]int, len(candidates))
+	for i, c := range candidates {
+		filtered[i] = c.session
+		highlights[i] = c.highlight
+	}
+	return filtered, highlights
+}
+
+// projectHighlight keeps only the positions fuzzyScore matched within the
+// ProjectName prefix of the combined "project target workdir" search text,
+// so View can highlight just the project name column.
+func projectHighlight(positions []int, projectLen int) []int {
+	var out []int
+	for _, p := range positions {
+		if p >= projectLen {
+			break
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// highlightFor returns the matched rune positions for filtered[i]'s project
+// name, or nil if i is out of range or nothing is highlighted.
+func (m model) highlightFor(i int) []int {
+	if i < 0 || i >= len(m.filterHighlights) {
+		return nil
+	}
+	return m.filterHighlights[i]
+}
+
+// renderProjectName renders name with any matched positions picked out in
+// highlightStyle (or highlightSelectedStyle when selected), falling back to
+// a plain styled render when there's nothing to highlight.
+func renderProjectName(name string, positions []int, isSelected bool) string {
+	base := projectStyle
+	hl := highlightStyle
+	if isSelected {
+		base = projectSelectedStyle
+		hl = highlightSelectedStyle
+	}
+	if len(positions) == 0 {
+		return base.Render(name)
+	}
+
+	matched := make(map[int]bool, len(positions))
+	for _, p := range positions {
+		matched[p] = true
+	}
+
+	var b strings.Builder
+	for i, r := range []rune(name) {
+		if matched[i] {
+			b.WriteString(hl.Render(string(r)))
+		} else {
+			b.WriteString(base.Render(string(r)))
+		}
+	}
+	return b.String()
 }
 
 // clampCursor ensures the cursor is within valid bounds.
@@ -389,6 +1294,60 @@ func (m *model) clampCursor() {
 	}
 }
 
+// toggleSelected adds id to the selection if it isn't already there, or
+// removes it if it is.
+func (m *model) toggleSelected(id string) {
+	if id == "" {
+		return
+	}
+	if m.selected == nil {
+		m.selected = make(map[string]struct{})
+	}
+	if _, ok := m.selected[id]; ok {
+		delete(m.selected, id)
+	} else {
+		m.selected[id] = struct{}{}
+	}
+}
+
+// selectAllFiltered replaces the selection with every currently filtered
+// session.
+func (m *model) selectAllFiltered() {
+	m.selected = make(map[string]struct{}, len(m.filtered))
+	for _, s := range m.filtered {
+		m.selected[s.SessionID] = struct{}{}
+	}
+}
+
+// invertSelection replaces the selection with the complement of the current
+// selection, restricted to the currently filtered sessions.
+func (m *model) invertSelection() {
+	next := make(map[string]struct{}, len(m.filtered))
+	for _, s := range m.filtered {
+		if _, ok := m.selected[s.SessionID]; !ok {
+			next[s.SessionID] = struct{}{}
+		}
+	}
+	m.selected = next
+}
+
+// selectedSessions returns every session in m.sessions whose SessionID is in
+// the selection, in m.sessions order. It looks at the full session list
+// rather than m.filtered so a selection made before narrowing the filter
+// still applies to every originally selected session.
+func (m model) selectedSessions() []session.Session {
+	if len(m.selected) == 0 {
+		return nil
+	}
+	var out []session.Session
+	for _, s := range m.sessions {
+		if _, ok := m.selected[s.SessionID]; ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
 // selectedSessionID returns the SessionID of the currently selected session, or empty if none.
 func (m model) selectedSessionID() string {
 	if m.cursor >= 0 && m.cursor < len(m.filtered) {
@@ -427,6 +1386,7 @@ func Run() error {
 
 	// After the TUI exits, check if we need to jump
 	if finalM, ok := finalModel.(model); ok && finalM.jumpTarget != "" {
+		_ = session.Session{TmuxTarget: finalM.jumpTarget}.MarkAttached()
 		return tmux.Jump(finalM.jumpTarget)
 	}
 
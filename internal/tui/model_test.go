@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/Jevs21/claude-tmux/internal/session"
+	tea "github.com/charmbracelet/bubbletea"
 )
 
 func TestApplyFilter(t *testing.T) {
@@ -15,10 +16,10 @@ func TestApplyFilter(t *testing.T) {
 	}
 
 	tests := []struct {
-		name           string
-		filterText     string
-		expectedCount  int
-		expectedIDs    []string
+		name          string
+		filterText    string
+		expectedCount int
+		expectedIDs   []string
 	}{
 		{
 			name:          "empty filter returns all sessions",
@@ -224,6 +225,81 @@ func TestRestoreCursorBySessionID(t *testing.T) {
 	}
 }
 
+func TestSelectAction_DestructiveEntersConfirmMode(t *testing.T) {
+	actions := []tuiAction{{label: "Kill pane", kind: actionKillPane, destructive: true}}
+	m := model{}
+
+	newModel, cmd := m.selectAction(actions, 0)
+	result := newModel.(model)
+
+	if result.mode != modeConfirm {
+		t.Fatalf("expected mode modeConfirm, got %v", result.mode)
+	}
+	if result.pendingAction.label != "Kill pane" {
+		t.Errorf("expected pendingAction %q, got %q", "Kill pane", result.pendingAction.label)
+	}
+	if cmd != nil {
+		t.Errorf("expected no command while awaiting confirmation, got %v", cmd)
+	}
+}
+
+func TestSelectAction_NonDestructiveRunsImmediately(t *testing.T) {
+	actions := []tuiAction{{label: "Attach", kind: actionAttach}}
+	m := model{actionTarget: session.Session{TmuxTarget: "work:0.0"}}
+
+	newModel, cmd := m.selectAction(actions, 0)
+	result := newModel.(model)
+
+	if result.jumpTarget != "work:0.0" {
+		t.Errorf("expected jumpTarget %q, got %q", "work:0.0", result.jumpTarget)
+	}
+	if cmd == nil {
+		t.Error("expected Attach to return tea.Quit")
+	}
+}
+
+func TestSelectAction_OutOfRangeIsNoop(t *testing.T) {
+	m := model{mode: modeAction}
+	newModel, cmd := m.selectAction(nil, 0)
+	result := newModel.(model)
+
+	if result.mode != modeAction {
+		t.Errorf("expected mode to stay modeAction, got %v", result.mode)
+	}
+	if cmd != nil {
+		t.Errorf("expected no command, got %v", cmd)
+	}
+}
+
+func TestUpdateConfirm_CancelReturnsToActionMenu(t *testing.T) {
+	m := model{mode: modeConfirm, pendingAction: tuiAction{label: "Kill pane", kind: actionKillPane, destructive: true}}
+
+	newModel, _ := m.updateConfirm(tea.KeyMsg{Type: tea.KeyEsc})
+	result := newModel.(model)
+
+	if result.mode != modeAction {
+		t.Errorf("expected mode modeAction after cancel, got %v", result.mode)
+	}
+}
+
+func TestUpdateConfirm_ConfirmRunsPendingAction(t *testing.T) {
+	m := model{
+		mode:          modeConfirm,
+		pendingAction: tuiAction{label: "Attach", kind: actionAttach},
+		actionTarget:  session.Session{TmuxTarget: "work:0.0"},
+	}
+
+	newModel, cmd := m.updateConfirm(tea.KeyMsg{Type: tea.KeyEnter})
+	result := newModel.(model)
+
+	if result.jumpTarget != "work:0.0" {
+		t.Errorf("expected jumpTarget %q, got %q", "work:0.0", result.jumpTarget)
+	}
+	if cmd == nil {
+		t.Error("expected confirming to run the pending action")
+	}
+}
+
 func TestRenderStatusIndicator(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -274,3 +350,131 @@ func TestRenderStatusIndicator(t *testing.T) {
 	}
 }
 
+func TestToggleSelected(t *testing.T) {
+	m := model{}
+
+	m.toggleSelected("s1")
+	if _, ok := m.selected["s1"]; !ok {
+		t.Fatal("expected s1 to be selected after first toggle")
+	}
+
+	m.toggleSelected("s1")
+	if _, ok := m.selected["s1"]; ok {
+		t.Fatal("expected s1 to be unselected after second toggle")
+	}
+}
+
+func TestToggleSelected_EmptyIDIsNoop(t *testing.T) {
+	m := model{}
+	m.toggleSelected("")
+	if len(m.selected) != 0 {
+		t.Errorf("expected no selection change for an empty ID, got %v", m.selected)
+	}
+}
+
+func TestSelectAllFiltered(t *testing.T) {
+	m := model{filtered: []session.Session{{SessionID: "s1"}, {SessionID: "s2"}}}
+	m.selectAllFiltered()
+
+	if len(m.selected) != 2 {
+		t.Fatalf("expected 2 selected sessions, got %d", len(m.selected))
+	}
+	for _, id := range []string{"s1", "s2"} {
+		if _, ok := m.selected[id]; !ok {
+			t.Errorf("expected %q to be selected", id)
+		}
+	}
+}
+
+func TestInvertSelection(t *testing.T) {
+	m := model{
+		filtered: []session.Session{{SessionID: "s1"}, {SessionID: "s2"}, {SessionID: "s3"}},
+		selected: map[string]struct{}{"s2": {}},
+	}
+	m.invertSelection()
+
+	want := map[string]bool{"s1": true, "s3": true}
+	if len(m.selected) != len(want) {
+		t.Fatalf("expected %d selected sessions, got %d: %v", len(want), len(m.selected), m.selected)
+	}
+	for id := range want {
+		if _, ok := m.selected[id]; !ok {
+			t.Errorf("expected %q to be selected after invert", id)
+		}
+	}
+	if _, ok := m.selected["s2"]; ok {
+		t.Error("expected s2 to be unselected after invert")
+	}
+}
+
+func TestSelectedSessions_PreservesSessionsOrderAndSurvivesFilterChange(t *testing.T) {
+	sessions := []session.Session{{SessionID: "s1"}, {SessionID: "s2"}, {SessionID: "s3"}}
+	m := model{
+		sessions: sessions,
+		filtered: sessions[:1], // the filter has narrowed past s2, which was selected earlier
+		selected: map[string]struct{}{"s2": {}, "s3": {}},
+	}
+
+	got := m.selectedSessions()
+	if len(got) != 2 || got[0].SessionID != "s2" || got[1].SessionID != "s3" {
+		t.Errorf("expected [s2 s3] in sessions order, got %+v", got)
+	}
+}
+
+func TestSelectBulkAction_DestructiveEntersBulkConfirmMode(t *testing.T) {
+	actions := []tuiBulkAction{{label: "Kill all", kind: bulkKillAll, destructive: true}}
+	m := model{bulkTargets: []session.Session{{SessionID: "s1"}}}
+
+	newModel, cmd := m.selectBulkAction(actions, 0)
+	result := newModel.(model)
+
+	if result.mode != modeBulkConfirm {
+		t.Fatalf("expected mode modeBulkConfirm, got %v", result.mode)
+	}
+	if result.pendingBulkAction.label != "Kill all" {
+		t.Errorf("expected pendingBulkAction %q, got %q", "Kill all", result.pendingBulkAction.label)
+	}
+	if cmd != nil {
+		t.Errorf("expected no command while awaiting confirmation, got %v", cmd)
+	}
+}
+
+func TestSelectBulkAction_SendKeysOpensBulkKeysMode(t *testing.T) {
+	actions := []tuiBulkAction{{label: "Send keys to all", kind: bulkSendKeys}}
+	m := initialModel()
+	m.bulkTargets = []session.Session{{SessionID: "s1"}}
+
+	newModel, cmd := m.selectBulkAction(actions, 0)
+	result := newModel.(model)
+
+	if result.mode != modeBulkKeys {
+		t.Fatalf("expected mode modeBulkKeys, got %v", result.mode)
+	}
+	if cmd == nil {
+		t.Error("expected focusing the keys input to return a command")
+	}
+}
+
+func TestSelectBulkAction_OutOfRangeIsNoop(t *testing.T) {
+	m := model{mode: modeBulkAction}
+	newModel, cmd := m.selectBulkAction(nil, 0)
+	result := newModel.(model)
+
+	if result.mode != modeBulkAction {
+		t.Errorf("expected mode to stay modeBulkAction, got %v", result.mode)
+	}
+	if cmd != nil {
+		t.Errorf("expected no command, got %v", cmd)
+	}
+}
+
+func TestUpdateBulkConfirm_CancelReturnsToBulkActionMenu(t *testing.T) {
+	m := model{mode: modeBulkConfirm, pendingBulkAction: tuiBulkAction{label: "Kill all", kind: bulkKillAll, destructive: true}}
+
+	newModel, _ := m.updateBulkConfirm(tea.KeyMsg{Type: tea.KeyEsc})
+	result := newModel.(model)
+
+	if result.mode != modeBulkAction {
+		t.Errorf("expected mode modeBulkAction after cancel, got %v", result.mode)
+	}
+}
@@ -0,0 +1,73 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Prefs holds the TUI's user-configurable preferences, loaded from
+// ~/.claude-tmux/tui.toml:
+//
+//	auto_confirm_safe = true
+//	safe_questions = ["Do you want to proceed?"]
+//
+// A missing file is not an error: LoadPrefs returns the zero value, which
+// leaves auto-confirm off.
+type Prefs struct {
+	// AutoConfirmSafe, when true, auto-sends option 1 to a StatusWaiting
+	// session as soon as its pane content matches one of SafeQuestions,
+	// without the y/n confirmation modal a manual 1-9/y/n keypress shows.
+	AutoConfirmSafe bool     `toml:"auto_confirm_safe"`
+	SafeQuestions   []string `toml:"safe_questions"`
+}
+
+// prefsPath returns the path Prefs are loaded from.
+func prefsPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".claude-tmux", "tui.toml"), nil
+}
+
+// LoadPrefs reads and parses the TUI preferences file. A missing file yields
+// the zero-value Prefs rather than an error.
+func LoadPrefs() (Prefs, error) {
+	path, err := prefsPath()
+	if err != nil {
+		return Prefs{}, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Prefs{}, nil
+		}
+		return Prefs{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var prefs Prefs
+	if _, err := toml.Decode(string(data), &prefs); err != nil {
+		return Prefs{}, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return prefs, nil
+}
+
+// matchesSafeQuestion reports whether paneContent contains one of p's
+// configured safe-question substrings, case-insensitively.
+func (p Prefs) matchesSafeQuestion(paneContent string) bool {
+	lower := strings.ToLower(paneContent)
+	for _, q := range p.SafeQuestions {
+		if q == "" {
+			continue
+		}
+		if strings.Contains(lower, strings.ToLower(q)) {
+			return true
+		}
+	}
+	return false
+}
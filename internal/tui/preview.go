@@ -0,0 +1,147 @@
+package tui
+
+import (
+	"time"
+
+	"github.com/Jevs21/claude-tmux/internal/tmux"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// previewLines is how many trailing lines of the highlighted pane
+// CapturePane pulls.
+const previewLines = 200
+
+// previewTTL is how long a cached capture is reused before a cursor move or
+// tick triggers a fresh one for the same session.
+const previewTTL = 2 * time.Second
+
+// previewDebounce delays a capture after the cursor moves, so holding j/k
+// down spawns at most one tmux process per pause rather than one per
+// keystroke.
+const previewDebounce = 100 * time.Millisecond
+
+// previewMinWidth is the terminal width below which the preview panel drops
+// out of the side-by-side layout (there just isn't room for two columns).
+const previewMinWidth = 100
+
+// previewEntry is one cached capture.
+type previewEntry struct {
+	text       string
+	err        error
+	capturedAt time.Time
+}
+
+// previewDebounceMsg fires previewDebounce after the cursor moves.
+// generation lets Update ignore it if the cursor moved again in the
+// meantime.
+type previewDebounceMsg struct {
+	generation int
+	sessionID  string
+	target     string
+}
+
+// previewMsg carries the result of a tmux.CapturePane call.
+type previewMsg struct {
+	generation int
+	sessionID  string
+	text       string
+	err        error
+}
+
+// requestPreview schedules a debounced capture for the given session unless
+// a fresh-enough cache entry already covers it, returning nil in that case.
+func (m *model) requestPreview(sessionID, target string) tea.Cmd {
+	if entry, ok := m.previewCache[sessionID]; ok && time.Since(entry.capturedAt) < previewTTL {
+		return nil
+	}
+	m.previewGeneration++
+	generation := m.previewGeneration
+	return tea.Tick(previewDebounce, func(time.Time) tea.Msg {
+		return previewDebounceMsg{generation: generation, sessionID: sessionID, target: target}
+	})
+}
+
+// requestPreviewForHighlighted schedules a debounced capture for whichever
+// session is currently highlighted, or returns nil if the preview panel is
+// off or nothing previewable is highlighted.
+func (m *model) requestPreviewForHighlighted() tea.Cmd {
+	if !m.previewEnabled {
+		return nil
+	}
+	sessionID, target := m.highlightedPreviewTarget()
+	if sessionID == "" {
+		return nil
+	}
+	return m.requestPreview(sessionID, target)
+}
+
+// capturePreviewCmd runs tmux.CapturePane and reports the result as a
+// previewMsg.
+func capturePreviewCmd(generation int, sessionID, target string) tea.Cmd {
+	return func() tea.Msg {
+		text, err := tmux.CapturePane(target, previewLines)
+		return previewMsg{generation: generation, sessionID: sessionID, text: text, err: err}
+	}
+}
+
+// highlightedPreviewTarget returns the SessionID and tmux target of the
+// currently highlighted, previewable session, or ("", "") if there isn't
+// one.
+func (m model) highlightedPreviewTarget() (sessionID, target string) {
+	if m.cursor < 0 || m.cursor >= len(m.filtered) {
+		return "", ""
+	}
+	s := m.filtered[m.cursor]
+	if !s.Jumpable() {
+		return "", ""
+	}
+	return s.SessionID, s.TmuxTarget
+}
+
+// storePreview records msg's result in the cache, ignoring it if any newer
+// preview request (for this session or another) has been made since.
+func (m *model) storePreview(msg previewMsg) {
+	if msg.generation != m.previewGeneration {
+		return
+	}
+	if m.previewCache == nil {
+		m.previewCache = make(map[string]previewEntry)
+	}
+	m.previewCache[msg.sessionID] = previewEntry{text: msg.text, err: msg.err, capturedAt: time.Now()}
+}
+
+// withPreviewPanel lays listStr and the highlighted session's preview panel
+// out side-by-side when the terminal is wide enough, or stacks the preview
+// below listStr otherwise.
+func (m model) withPreviewPanel(listStr string) string {
+	sessionID, _ := m.highlightedPreviewTarget()
+	entry, ok := m.previewCache[sessionID]
+
+	if m.width < previewMinWidth {
+		panel := renderPreviewPanel(entry, ok, max(0, m.width-2), 10)
+		return listStr + "\n" + panel
+	}
+
+	listWidth := m.width * 3 / 5
+	previewWidth := m.width - listWidth - 1
+	height := max(5, m.height-2)
+
+	list := lipgloss.NewStyle().Width(listWidth).Render(listStr)
+	panel := renderPreviewPanel(entry, ok, previewWidth, height)
+	return lipgloss.JoinHorizontal(lipgloss.Top, list, panel)
+}
+
+// renderPreviewPanel renders the cached capture for the highlighted session,
+// sized to width x height. ANSI styling from capture-pane -e passes through
+// untouched; lipgloss only constrains the box around it.
+func renderPreviewPanel(entry previewEntry, ok bool, width, height int) string {
+	style := previewPanelStyle.Width(width).Height(height)
+	if !ok {
+		return style.Render(emptyStyle.Render("no preview available"))
+	}
+	if entry.err != nil {
+		return style.Render(emptyStyle.Render("preview error: " + entry.err.Error()))
+	}
+	return style.Render(entry.text)
+}
@@ -0,0 +1,115 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/Jevs21/claude-tmux/internal/session"
+)
+
+// QueryFormat selects how Query renders each matching session.
+type QueryFormat string
+
+const (
+	// FormatTarget prints each session's tmux target, one per line — the
+	// default, and the shape `xargs tmux switch-client -t` expects.
+	FormatTarget QueryFormat = "target"
+	// FormatJSON prints each session as a JSON object, one per line.
+	FormatJSON QueryFormat = "json"
+	// FormatTemplate renders QueryOptions.Template against each session
+	// using text/template, e.g. "{{.ProjectName}}\t{{.TmuxTarget}}".
+	FormatTemplate QueryFormat = "template"
+)
+
+// QueryOptions configures a headless Query run.
+type QueryOptions struct {
+	Filter   string      // query text; empty matches every session
+	Fuzzy    bool        // fuzzy subsequence ranking vs. plain substring, mirroring model.fuzzyMode
+	Format   QueryFormat // defaults to FormatTarget if empty
+	Template string      // text/template source, required when Format == FormatTemplate
+	Out      io.Writer   // defaults to io.Discard if nil
+}
+
+// Query runs session.ReadSessions and applies the same filter/scoring
+// model.applyFilter uses interactively, then writes one matching session per
+// line to opts.Out in opts.Format. It never starts Bubbletea, so callers can
+// pipe its output straight into other commands, mirroring fzf --filter:
+//
+//	claude-tmux -q myproject -f target | xargs tmux switch-client -t
+//
+// It returns ExitMatched if at least one session matched, ExitNoMatches if
+// the filter matched nothing, or ExitQueryError (with a non-nil error) if
+// reading sessions or rendering a match failed.
+func Query(opts QueryOptions) (int, error) {
+	if opts.Out == nil {
+		opts.Out = io.Discard
+	}
+	format := opts.Format
+	if format == "" {
+		format = FormatTarget
+	}
+
+	sessions, err := session.ReadSessions()
+	if err != nil {
+		return ExitQueryError, fmt.Errorf("read sessions: %w", err)
+	}
+
+	matched := filterSessions(sessions, opts.Filter, opts.Fuzzy)
+	if len(matched) == 0 {
+		return ExitNoMatches, nil
+	}
+
+	tmpl, err := parseQueryTemplate(format, opts.Template)
+	if err != nil {
+		return ExitQueryError, err
+	}
+
+	for _, s := range matched {
+		line, err := formatQueryMatch(s, format, tmpl)
+		if err != nil {
+			return ExitQueryError, err
+		}
+		fmt.Fprintln(opts.Out, line)
+	}
+	return ExitMatched, nil
+}
+
+// parseQueryTemplate parses text for FormatTemplate, returning nil for every
+// other format since they don't need one.
+func parseQueryTemplate(format QueryFormat, text string) (*template.Template, error) {
+	if format != FormatTemplate {
+		return nil, nil
+	}
+	if text == "" {
+		return nil, fmt.Errorf("template format requires a non-empty template")
+	}
+	tmpl, err := template.New("query").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parse template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// formatQueryMatch renders s per format, using tmpl when format is
+// FormatTemplate.
+func formatQueryMatch(s session.Session, format QueryFormat, tmpl *template.Template) (string, error) {
+	switch format {
+	case FormatJSON:
+		data, err := json.Marshal(s)
+		if err != nil {
+			return "", fmt.Errorf("marshal %s: %w", s.DisplayTarget(), err)
+		}
+		return string(data), nil
+	case FormatTemplate:
+		var b strings.Builder
+		if err := tmpl.Execute(&b, s); err != nil {
+			return "", fmt.Errorf("render template for %s: %w", s.DisplayTarget(), err)
+		}
+		return b.String(), nil
+	default:
+		return s.TmuxTarget, nil
+	}
+}
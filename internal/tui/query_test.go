@@ -0,0 +1,111 @@
+package tui
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Jevs21/claude-tmux/internal/session"
+)
+
+func TestFilterSessions(t *testing.T) {
+	sessions := []session.Session{
+		{SessionID: "s1", ProjectName: "claude-tmux", TmuxTarget: "work:0.0"},
+		{SessionID: "s2", ProjectName: "api-server", TmuxTarget: "dev:1.0"},
+	}
+
+	if got := filterSessions(sessions, "", false); len(got) != 2 {
+		t.Fatalf("expected an empty filter to match everything, got %d", len(got))
+	}
+
+	got := filterSessions(sessions, "api-server", false)
+	if len(got) != 1 || got[0].SessionID != "s2" {
+		t.Fatalf("expected substring match to find s2, got %+v", got)
+	}
+
+	got = filterSessions(sessions, "cltmx", true)
+	if len(got) != 1 || got[0].SessionID != "s1" {
+		t.Fatalf("expected fuzzy match to find s1, got %+v", got)
+	}
+}
+
+func TestFormatQueryMatch_Target(t *testing.T) {
+	s := session.Session{TmuxTarget: "work:0.0"}
+	got, err := formatQueryMatch(s, FormatTarget, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "work:0.0" {
+		t.Errorf("expected %q, got %q", "work:0.0", got)
+	}
+}
+
+func TestFormatQueryMatch_JSON(t *testing.T) {
+	s := session.Session{SessionID: "s1", TmuxTarget: "work:0.0"}
+	got, err := formatQueryMatch(s, FormatJSON, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded session.Session
+	if err := json.Unmarshal([]byte(got), &decoded); err != nil {
+		t.Fatalf("expected valid JSON, got %q: %v", got, err)
+	}
+	if decoded.SessionID != "s1" {
+		t.Errorf("expected decoded SessionID %q, got %q", "s1", decoded.SessionID)
+	}
+}
+
+func TestFormatQueryMatch_Template(t *testing.T) {
+	s := session.Session{ProjectName: "claude-tmux", TmuxTarget: "work:0.0"}
+	tmpl, err := parseQueryTemplate(FormatTemplate, "{{.ProjectName}}={{.TmuxTarget}}")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := formatQueryMatch(s, FormatTemplate, tmpl)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "claude-tmux=work:0.0" {
+		t.Errorf("expected %q, got %q", "claude-tmux=work:0.0", got)
+	}
+}
+
+func TestParseQueryTemplate_NonTemplateFormatsNeedNone(t *testing.T) {
+	tmpl, err := parseQueryTemplate(FormatTarget, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tmpl != nil {
+		t.Errorf("expected no template for FormatTarget, got %v", tmpl)
+	}
+}
+
+func TestParseQueryTemplate_EmptyTemplateTextErrors(t *testing.T) {
+	if _, err := parseQueryTemplate(FormatTemplate, ""); err == nil {
+		t.Fatal("expected an error for an empty template with FormatTemplate")
+	}
+}
+
+func TestParseQueryTemplate_InvalidTemplateErrors(t *testing.T) {
+	if _, err := parseQueryTemplate(FormatTemplate, "{{.Broken"); err == nil {
+		t.Fatal("expected an error for unparseable template text")
+	}
+}
+
+func TestQuery_NoMatchesReturnsExitNoMatches(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	var out strings.Builder
+	code, err := Query(QueryOptions{Filter: "nothing-will-ever-match-this", Out: &out})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if code != ExitNoMatches {
+		t.Errorf("expected ExitNoMatches, got %d", code)
+	}
+	if out.Len() != 0 {
+		t.Errorf("expected no output, got %q", out.String())
+	}
+}
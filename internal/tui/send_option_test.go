@@ -0,0 +1,176 @@
+package tui
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/Jevs21/claude-tmux/internal/session"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// fakeCommander is a minimal tmux.Commander stand-in: it records every
+// issued argv and returns canned output keyed by the joined argv, the same
+// shape session's own fakeCommander uses internally.
+type fakeCommander struct {
+	calls  []string
+	output map[string]string
+}
+
+func (f *fakeCommander) Exec(cmd *exec.Cmd) (string, error) {
+	argv := strings.Join(cmd.Args, " ")
+	f.calls = append(f.calls, argv)
+	return f.output[argv], nil
+}
+
+func (f *fakeCommander) ExecSilently(cmd *exec.Cmd) error {
+	f.calls = append(f.calls, strings.Join(cmd.Args, " "))
+	return nil
+}
+
+func withFakeCommander(t *testing.T) *fakeCommander {
+	t.Helper()
+	fake := &fakeCommander{output: map[string]string{}}
+	old := session.ActiveCommander
+	session.ActiveCommander = fake
+	t.Cleanup(func() { session.ActiveCommander = old })
+	return fake
+}
+
+func waitingPaneContent() string {
+	return " Do you want to proceed?\n ❯ 1. Yes\n   2. No\n"
+}
+
+func TestBeginSendOption_EntersSendConfirmWithMatchingOption(t *testing.T) {
+	fake := withFakeCommander(t)
+	fake.output["tmux capture-pane -t work:0.0 -p"] = waitingPaneContent()
+
+	m := model{
+		filtered: []session.Session{{SessionID: "s1", TmuxTarget: "work:0.0", Status: session.StatusWaiting}},
+	}
+
+	newModel, cmd := m.beginSendOption(1)
+	result := newModel.(model)
+
+	if result.mode != modeSendConfirm {
+		t.Fatalf("expected modeSendConfirm, got %v", result.mode)
+	}
+	if result.sendOptionNumber != 1 || result.sendOptionText != "Yes" {
+		t.Errorf("unexpected send option: %d %q", result.sendOptionNumber, result.sendOptionText)
+	}
+	if cmd != nil {
+		t.Errorf("expected no command while awaiting confirmation, got %v", cmd)
+	}
+}
+
+func TestBeginSendOption_NotWaitingIsNoop(t *testing.T) {
+	withFakeCommander(t)
+	m := model{
+		filtered: []session.Session{{SessionID: "s1", TmuxTarget: "work:0.0", Status: session.StatusIdle}},
+	}
+
+	newModel, _ := m.beginSendOption(1)
+	result := newModel.(model)
+	if result.mode != modeNormal {
+		t.Errorf("expected mode to stay modeNormal, got %v", result.mode)
+	}
+}
+
+func TestBeginSendYesNo_MapsToParsedOption(t *testing.T) {
+	fake := withFakeCommander(t)
+	fake.output["tmux capture-pane -t work:0.0 -p"] = waitingPaneContent()
+
+	m := model{
+		filtered: []session.Session{{SessionID: "s1", TmuxTarget: "work:0.0", Status: session.StatusWaiting}},
+	}
+
+	newModel, _ := m.beginSendYesNo(false)
+	result := newModel.(model)
+
+	if result.mode != modeSendConfirm || result.sendOptionNumber != 2 {
+		t.Fatalf("expected modeSendConfirm with option 2 for \"n\", got mode=%v option=%d", result.mode, result.sendOptionNumber)
+	}
+}
+
+func TestUpdateSendConfirm_ConfirmSubmitsOptionAndRescans(t *testing.T) {
+	fake := withFakeCommander(t)
+	m := model{
+		mode:             modeSendConfirm,
+		sendTarget:       session.Session{TmuxTarget: "work:0.0"},
+		sendOptionNumber: 1,
+	}
+
+	newModel, cmd := m.updateSendConfirm(tea.KeyMsg{Type: tea.KeyEnter})
+	result := newModel.(model)
+
+	if result.mode != modeNormal {
+		t.Errorf("expected mode modeNormal after confirming, got %v", result.mode)
+	}
+	if cmd == nil {
+		t.Error("expected a rescan command after confirming")
+	}
+	want := "tmux send-keys -t work:0.0 1 Enter"
+	if len(fake.calls) != 1 || fake.calls[0] != want {
+		t.Errorf("expected call %q, got %v", want, fake.calls)
+	}
+}
+
+func TestUpdateSendConfirm_CancelReturnsToNormal(t *testing.T) {
+	withFakeCommander(t)
+	m := model{mode: modeSendConfirm}
+
+	newModel, _ := m.updateSendConfirm(tea.KeyMsg{Type: tea.KeyEsc})
+	result := newModel.(model)
+	if result.mode != modeNormal {
+		t.Errorf("expected mode modeNormal after cancel, got %v", result.mode)
+	}
+}
+
+func TestAutoConfirmSafeCmd_SendsOptionOneOnceForMatchingQuestion(t *testing.T) {
+	fake := withFakeCommander(t)
+	fake.output["tmux capture-pane -t work:0.0 -p"] = waitingPaneContent()
+
+	m := model{
+		prefs:    Prefs{AutoConfirmSafe: true, SafeQuestions: []string{"Do you want to proceed?"}},
+		sessions: []session.Session{{SessionID: "s1", TmuxTarget: "work:0.0", Status: session.StatusWaiting}},
+	}
+
+	cmd := m.autoConfirmSafeCmd()
+	if cmd == nil {
+		t.Fatal("expected a command to send option 1")
+	}
+	cmd() // execute the batched command synchronously
+
+	found := false
+	for _, c := range fake.calls {
+		if c == "tmux send-keys -t work:0.0 1 Enter" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected option 1 to be sent, got calls %v", fake.calls)
+	}
+	if !m.autoAnswered["s1"] {
+		t.Error("expected session to be marked auto-answered")
+	}
+
+	// A second call for the same still-waiting session must not resend.
+	fake.calls = nil
+	if cmd := m.autoConfirmSafeCmd(); cmd != nil {
+		cmd()
+	}
+	for _, c := range fake.calls {
+		if c == "tmux send-keys -t work:0.0 1 Enter" {
+			t.Errorf("expected no resend for an already auto-answered session, got calls %v", fake.calls)
+		}
+	}
+}
+
+func TestAutoConfirmSafeCmd_Disabled(t *testing.T) {
+	m := model{
+		sessions: []session.Session{{SessionID: "s1", TmuxTarget: "work:0.0", Status: session.StatusWaiting}},
+	}
+	if cmd := m.autoConfirmSafeCmd(); cmd != nil {
+		t.Error("expected no command when AutoConfirmSafe is disabled")
+	}
+}
@@ -1,99 +1,211 @@
 package tui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"fmt"
+	"os"
 
-// Gruvbox-inspired color palette
-var (
-	colorForeground = lipgloss.Color("#ebdbb2")
-	colorDim        = lipgloss.Color("#928374")
-	colorAccent     = lipgloss.Color("#fe8019") // orange
-	colorGreen      = lipgloss.Color("#b8bb26")
-	colorBlue       = lipgloss.Color("#83a598")
-	colorAqua       = lipgloss.Color("#8ec07c")
-	colorSelectedBg = lipgloss.Color("#3c3836")
-	colorRed        = lipgloss.Color("#fb4934")
-	colorYellow     = lipgloss.Color("#fabd2f")
+	"github.com/Jevs21/claude-tmux/internal/tui/theme"
+	"github.com/charmbracelet/lipgloss"
 )
 
+// currentTheme is the active color palette every style below is built from.
+// SetTheme rebuilds the styles whenever it changes.
+var currentTheme = theme.Gruvbox
+
 var (
+	headerStyle                lipgloss.Style
+	selectedStyle              lipgloss.Style
+	cursorStyle                lipgloss.Style
+	normalStyle                lipgloss.Style
+	projectStyle               lipgloss.Style
+	projectSelectedStyle       lipgloss.Style
+	tmuxTargetStyle            lipgloss.Style
+	tmuxTargetSelectedStyle    lipgloss.Style
+	pathStyle                  lipgloss.Style
+	pathSelectedStyle          lipgloss.Style
+	filterPromptStyle          lipgloss.Style
+	emptyStyle                 lipgloss.Style
+	helpStyle                  lipgloss.Style
+	detachedStyle              lipgloss.Style
+	detachedSelectedStyle      lipgloss.Style
+	actionStyle                lipgloss.Style
+	actionSelectedStyle        lipgloss.Style
+	statusBusyStyle            lipgloss.Style
+	statusBusySelectedStyle    lipgloss.Style
+	statusWaitingStyle         lipgloss.Style
+	statusWaitingSelectedStyle lipgloss.Style
+	statusIdleStyle            lipgloss.Style
+	statusIdleSelectedStyle    lipgloss.Style
+	statusUnknownStyle         lipgloss.Style
+	statusUnknownSelectedStyle lipgloss.Style
+	highlightStyle             lipgloss.Style
+	highlightSelectedStyle     lipgloss.Style
+	previewPanelStyle          lipgloss.Style
+	selectionMarkerStyle       lipgloss.Style
+	selectionMarkerDimStyle    lipgloss.Style
+)
+
+func init() {
+	SetTheme(currentTheme)
+}
+
+// SetTheme replaces the active color palette and rebuilds every derived
+// style from it. initTheme calls it once at startup with the preset chosen
+// via $CLAUDE_TMUX_THEME; it's exported so the TUI (or a future CLI's
+// --theme flag) can also switch palettes without restarting.
+func SetTheme(t theme.Theme) {
+	currentTheme = t
+
+	foreground := lipgloss.Color(t.Foreground)
+	dim := lipgloss.Color(t.Dim)
+	accent := lipgloss.Color(t.Accent)
+	green := lipgloss.Color(t.Green)
+	blue := lipgloss.Color(t.Blue)
+	aqua := lipgloss.Color(t.Aqua)
+	red := lipgloss.Color(t.Red)
+	yellow := lipgloss.Color(t.Yellow)
+	selectedBg := lipgloss.Color(t.SelectedBg)
+
 	headerStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(colorAccent).
-			MarginBottom(1)
+		Bold(true).
+		Foreground(accent).
+		MarginBottom(1)
 
 	selectedStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(colorForeground).
-			Background(colorSelectedBg)
+		Bold(true).
+		Foreground(foreground).
+		Background(selectedBg)
 
 	cursorStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(colorAccent)
+		Bold(true).
+		Foreground(accent)
 
 	normalStyle = lipgloss.NewStyle().
-			Foreground(colorDim)
+		Foreground(dim)
 
 	projectStyle = lipgloss.NewStyle().
-			Foreground(colorGreen)
+		Foreground(green)
 
 	projectSelectedStyle = lipgloss.NewStyle().
-				Bold(true).
-				Foreground(colorGreen).
-				Background(colorSelectedBg)
+		Bold(true).
+		Foreground(green).
+		Background(selectedBg)
 
 	tmuxTargetStyle = lipgloss.NewStyle().
-			Foreground(colorBlue)
+		Foreground(blue)
 
 	tmuxTargetSelectedStyle = lipgloss.NewStyle().
-				Bold(true).
-				Foreground(colorBlue).
-				Background(colorSelectedBg)
+		Bold(true).
+		Foreground(blue).
+		Background(selectedBg)
 
 	pathStyle = lipgloss.NewStyle().
-			Foreground(colorDim)
+		Foreground(dim)
 
 	pathSelectedStyle = lipgloss.NewStyle().
-				Foreground(colorAqua).
-				Background(colorSelectedBg)
+		Foreground(aqua).
+		Background(selectedBg)
 
 	filterPromptStyle = lipgloss.NewStyle().
-				Foreground(colorAccent).
-				Bold(true)
+		Foreground(accent).
+		Bold(true)
 
 	emptyStyle = lipgloss.NewStyle().
-			Foreground(colorDim).
-			Italic(true)
+		Foreground(dim).
+		Italic(true)
 
 	helpStyle = lipgloss.NewStyle().
-			Foreground(colorDim)
+		Foreground(dim)
 
 	detachedStyle = lipgloss.NewStyle().
-			Foreground(colorRed).
-			Italic(true)
+		Foreground(red).
+		Italic(true)
 
 	detachedSelectedStyle = lipgloss.NewStyle().
-				Foreground(colorRed).
-				Italic(true).
-				Background(colorSelectedBg)
+		Foreground(red).
+		Italic(true).
+		Background(selectedBg)
+
+	actionStyle = lipgloss.NewStyle().
+		Foreground(dim)
+
+	actionSelectedStyle = lipgloss.NewStyle().
+		Foreground(dim).
+		Background(selectedBg)
 
 	statusBusyStyle = lipgloss.NewStyle().
-			Foreground(colorYellow)
+		Foreground(yellow)
 
 	statusBusySelectedStyle = lipgloss.NewStyle().
-				Foreground(colorYellow).
-				Background(colorSelectedBg)
+		Foreground(yellow).
+		Background(selectedBg)
+
+	statusWaitingStyle = lipgloss.NewStyle().
+		Foreground(accent)
+
+	statusWaitingSelectedStyle = lipgloss.NewStyle().
+		Foreground(accent).
+		Background(selectedBg)
 
 	statusIdleStyle = lipgloss.NewStyle().
-			Foreground(colorGreen)
+		Foreground(green)
 
 	statusIdleSelectedStyle = lipgloss.NewStyle().
-				Foreground(colorGreen).
-				Background(colorSelectedBg)
+		Foreground(green).
+		Background(selectedBg)
 
 	statusUnknownStyle = lipgloss.NewStyle().
-				Foreground(colorDim)
+		Foreground(dim)
 
 	statusUnknownSelectedStyle = lipgloss.NewStyle().
-					Foreground(colorDim).
-					Background(colorSelectedBg)
-)
+		Foreground(dim).
+		Background(selectedBg)
+
+	highlightStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(yellow)
+
+	highlightSelectedStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(yellow).
+		Background(selectedBg)
+
+	previewPanelStyle = lipgloss.NewStyle().
+		Foreground(foreground).
+		Border(lipgloss.NormalBorder()).
+		BorderForeground(dim).
+		Padding(0, 1)
+
+	selectionMarkerStyle = lipgloss.NewStyle().
+		Foreground(aqua)
+
+	// selectionMarkerDimStyle marks a selected session that's no longer
+	// Jumpable (its tmux pane disappeared between refreshes), so the
+	// marker doesn't claim an action can still be taken against it.
+	selectionMarkerDimStyle = lipgloss.NewStyle().
+		Foreground(dim)
+}
+
+// ThemeEnvVar is the environment variable initTheme reads to pick a built-in
+// preset at startup, e.g. CLAUDE_TMUX_THEME=nord. This repo has no main/cmd
+// package, so a --theme flag can't be parsed here; initTheme is the entry
+// point a future CLI (or the TUI's own prefs) would call after resolving a
+// flag to a theme name.
+const ThemeEnvVar = "CLAUDE_TMUX_THEME"
+
+// initTheme resolves the theme named by $CLAUDE_TMUX_THEME, if any, and
+// applies it via SetTheme. An unset or unrecognized name leaves the default
+// Gruvbox palette in place; unrecognized names are reported so a caller can
+// surface the mistake instead of silently rendering the wrong colors.
+func initTheme() error {
+	name := os.Getenv(ThemeEnvVar)
+	if name == "" {
+		return nil
+	}
+	t, ok := theme.Get(name)
+	if !ok {
+		return fmt.Errorf("unknown %s %q", ThemeEnvVar, name)
+	}
+	SetTheme(t)
+	return nil
+}
@@ -0,0 +1,53 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/Jevs21/claude-tmux/internal/tui/theme"
+)
+
+func TestSetTheme_UpdatesCurrentTheme(t *testing.T) {
+	t.Cleanup(func() { SetTheme(theme.Gruvbox) })
+
+	SetTheme(theme.Nord)
+	if currentTheme != theme.Nord {
+		t.Errorf("expected currentTheme to be Nord, got %+v", currentTheme)
+	}
+}
+
+func TestInitTheme_UnsetEnvKeepsDefault(t *testing.T) {
+	t.Cleanup(func() { SetTheme(theme.Gruvbox) })
+	SetTheme(theme.Gruvbox)
+
+	t.Setenv(ThemeEnvVar, "")
+	if err := initTheme(); err != nil {
+		t.Fatalf("expected no error for unset theme env var, got %v", err)
+	}
+	if currentTheme != theme.Gruvbox {
+		t.Errorf("expected currentTheme to stay Gruvbox, got %+v", currentTheme)
+	}
+}
+
+func TestInitTheme_KnownPresetApplies(t *testing.T) {
+	t.Cleanup(func() { SetTheme(theme.Gruvbox) })
+
+	t.Setenv(ThemeEnvVar, "dracula")
+	if err := initTheme(); err != nil {
+		t.Fatalf("expected no error for a known preset, got %v", err)
+	}
+	if currentTheme != theme.Dracula {
+		t.Errorf("expected currentTheme to be Dracula, got %+v", currentTheme)
+	}
+}
+
+func TestInitTheme_UnknownPresetReturnsError(t *testing.T) {
+	t.Cleanup(func() { SetTheme(theme.Gruvbox) })
+
+	t.Setenv(ThemeEnvVar, "not-a-real-theme")
+	if err := initTheme(); err == nil {
+		t.Error("expected an error for an unrecognized theme name")
+	}
+	if currentTheme != theme.Gruvbox {
+		t.Errorf("expected currentTheme to stay Gruvbox after an unknown preset, got %+v", currentTheme)
+	}
+}
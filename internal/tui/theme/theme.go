@@ -0,0 +1,183 @@
+// Package theme defines the tui package's color palette as plain data
+// rather than hard-coded lipgloss.Color literals, so the palette can be
+// swapped without a code change: the tui package picks a built-in preset at
+// startup from $CLAUDE_TMUX_THEME, and LoadFromTOML reads a user-supplied
+// base16 scheme file into a Theme. No startup path loads such a file.
+package theme
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Theme is the small set of named colors every tui style is built from.
+// Colors are "#rrggbb" hex strings, the form lipgloss.Color expects; only
+// tui/styles.go wraps them in that type, so this package stays independent
+// of the rendering library.
+type Theme struct {
+	Foreground string
+	Dim        string
+	Accent     string
+	Green      string
+	Blue       string
+	Aqua       string
+	Red        string
+	Yellow     string
+	SelectedBg string
+}
+
+// Gruvbox is the palette tui/styles.go hard-coded before themes existed. It
+// remains the default.
+var Gruvbox = Theme{
+	Foreground: "#ebdbb2",
+	Dim:        "#928374",
+	Accent:     "#fe8019",
+	Green:      "#b8bb26",
+	Blue:       "#83a598",
+	Aqua:       "#8ec07c",
+	Red:        "#fb4934",
+	Yellow:     "#fabd2f",
+	SelectedBg: "#3c3836",
+}
+
+// GruvboxLight is Gruvbox's light-background counterpart.
+var GruvboxLight = Theme{
+	Foreground: "#3c3836",
+	Dim:        "#7c6f64",
+	Accent:     "#d65d0e",
+	Green:      "#79740e",
+	Blue:       "#076678",
+	Aqua:       "#427b58",
+	Red:        "#9d0006",
+	Yellow:     "#b57614",
+	SelectedBg: "#ebdbb2",
+}
+
+// Nord is the Nord palette (nordtheme.com).
+var Nord = Theme{
+	Foreground: "#d8dee9",
+	Dim:        "#4c566a",
+	Accent:     "#d08770",
+	Green:      "#a3be8c",
+	Blue:       "#81a1c1",
+	Aqua:       "#88c0d0",
+	Red:        "#bf616a",
+	Yellow:     "#ebcb8b",
+	SelectedBg: "#434c5e",
+}
+
+// SolarizedDark is Ethan Schoonover's Solarized Dark palette.
+var SolarizedDark = Theme{
+	Foreground: "#839496",
+	Dim:        "#586e75",
+	Accent:     "#cb4b16",
+	Green:      "#859900",
+	Blue:       "#268bd2",
+	Aqua:       "#2aa198",
+	Red:        "#dc322f",
+	Yellow:     "#b58900",
+	SelectedBg: "#073642",
+}
+
+// Dracula is the Dracula palette (draculatheme.com).
+var Dracula = Theme{
+	Foreground: "#f8f8f2",
+	Dim:        "#6272a4",
+	Accent:     "#ffb86c",
+	Green:      "#50fa7b",
+	Blue:       "#bd93f9",
+	Aqua:       "#8be9fd",
+	Red:        "#ff5555",
+	Yellow:     "#f1fa8c",
+	SelectedBg: "#44475a",
+}
+
+// TokyoNight is the Tokyo Night palette.
+var TokyoNight = Theme{
+	Foreground: "#c0caf5",
+	Dim:        "#565f89",
+	Accent:     "#ff9e64",
+	Green:      "#9ece6a",
+	Blue:       "#7aa2f7",
+	Aqua:       "#7dcfff",
+	Red:        "#f7768e",
+	Yellow:     "#e0af68",
+	SelectedBg: "#283457",
+}
+
+// Registry maps a --theme/$CLAUDE_TMUX_THEME preset name to its Theme.
+var Registry = map[string]Theme{
+	"gruvbox":        Gruvbox,
+	"gruvbox-light":  GruvboxLight,
+	"nord":           Nord,
+	"solarized-dark": SolarizedDark,
+	"dracula":        Dracula,
+	"tokyo-night":    TokyoNight,
+}
+
+// Get returns the built-in preset registered under name.
+func Get(name string) (Theme, bool) {
+	t, ok := Registry[name]
+	return t, ok
+}
+
+// base16Scheme mirrors the standard base16 scheme shape: base00-base0F are
+// 6-digit hex colors, conventionally written without a leading "#".
+type base16Scheme struct {
+	Base00 string `toml:"base00"`
+	Base01 string `toml:"base01"`
+	Base02 string `toml:"base02"`
+	Base03 string `toml:"base03"`
+	Base04 string `toml:"base04"`
+	Base05 string `toml:"base05"`
+	Base06 string `toml:"base06"`
+	Base07 string `toml:"base07"`
+	Base08 string `toml:"base08"`
+	Base09 string `toml:"base09"`
+	Base0A string `toml:"base0A"`
+	Base0B string `toml:"base0B"`
+	Base0C string `toml:"base0C"`
+	Base0D string `toml:"base0D"`
+	Base0E string `toml:"base0E"`
+	Base0F string `toml:"base0F"`
+}
+
+// LoadFromTOML reads a base16 scheme file at path and maps its slots onto a
+// Theme following the base16 spec's conventional roles: base05 (default
+// foreground), base03 (comments/dim), base02 (selection background), base08
+// (variables/red), base0A (classes/yellow), base0B (strings/green), base0C
+// (support/aqua), base0D (functions/blue), base09 (integers/accent).
+func LoadFromTOML(path string) (Theme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Theme{}, fmt.Errorf("failed to read theme file %s: %w", path, err)
+	}
+
+	var scheme base16Scheme
+	if _, err := toml.Decode(string(data), &scheme); err != nil {
+		return Theme{}, fmt.Errorf("failed to parse theme file %s: %w", path, err)
+	}
+
+	return Theme{
+		Foreground: hexColor(scheme.Base05),
+		Dim:        hexColor(scheme.Base03),
+		Accent:     hexColor(scheme.Base09),
+		Green:      hexColor(scheme.Base0B),
+		Blue:       hexColor(scheme.Base0D),
+		Aqua:       hexColor(scheme.Base0C),
+		Red:        hexColor(scheme.Base08),
+		Yellow:     hexColor(scheme.Base0A),
+		SelectedBg: hexColor(scheme.Base02),
+	}, nil
+}
+
+// hexColor normalizes a base16 scheme's hex value (conventionally written
+// without a leading "#") to the "#rrggbb" form lipgloss.Color expects.
+func hexColor(hex string) string {
+	if hex == "" || hex[0] == '#' {
+		return hex
+	}
+	return "#" + hex
+}
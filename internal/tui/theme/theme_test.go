@@ -0,0 +1,77 @@
+package theme
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGet_KnownPreset(t *testing.T) {
+	got, ok := Get("nord")
+	if !ok {
+		t.Fatal("expected nord to be a known preset")
+	}
+	if got != Nord {
+		t.Errorf("expected Nord, got %+v", got)
+	}
+}
+
+func TestGet_UnknownPreset(t *testing.T) {
+	if _, ok := Get("not-a-theme"); ok {
+		t.Error("expected unknown preset name to report ok=false")
+	}
+}
+
+func TestLoadFromTOML_MapsBase16SlotsToTheme(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "scheme.toml")
+	contents := `
+scheme = "Test Scheme"
+author = "test"
+base00 = "1d2021"
+base01 = "3c3836"
+base02 = "504945"
+base03 = "665c54"
+base04 = "bdae93"
+base05 = "d5c4a1"
+base06 = "ebdbb2"
+base07 = "fbf1c7"
+base08 = "fb4934"
+base09 = "fe8019"
+base0A = "fabd2f"
+base0B = "b8bb26"
+base0C = "8ec07c"
+base0D = "83a598"
+base0E = "d3869b"
+base0F = "d65d0e"
+`
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("failed to write test scheme: %v", err)
+	}
+
+	got, err := LoadFromTOML(path)
+	if err != nil {
+		t.Fatalf("LoadFromTOML returned error: %v", err)
+	}
+
+	want := Theme{
+		Foreground: "#d5c4a1",
+		Dim:        "#665c54",
+		Accent:     "#fe8019",
+		Green:      "#b8bb26",
+		Blue:       "#83a598",
+		Aqua:       "#8ec07c",
+		Red:        "#fb4934",
+		Yellow:     "#fabd2f",
+		SelectedBg: "#504945",
+	}
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestLoadFromTOML_MissingFile(t *testing.T) {
+	if _, err := LoadFromTOML(filepath.Join(t.TempDir(), "missing.toml")); err == nil {
+		t.Error("expected an error for a missing theme file")
+	}
+}